@@ -0,0 +1,89 @@
+package cache
+
+import "container/list"
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LRU is a fixed capacity cache that evicts the least recently used entry once full
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List
+	onEvict  func(K, V)
+}
+
+// NewLRU creates a cache holding at most capacity entries. onEvict is optional and is
+// called with every entry pushed out to make room.
+func NewLRU[K comparable, V any](capacity int, onEvict func(K, V)) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value for a key and marks it as recently used
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	if el, ok := c.items[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*entry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek returns the value for a key without marking it as recently used
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	if el, ok := c.items[key]; ok {
+		return el.Value.(*entry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put adds or updates a value, evicting the least recently used entries if needed
+func (c *LRU[K, V]) Put(key K, value V) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+
+	for c.order.Len() > c.capacity {
+		c.evict(c.order.Back())
+	}
+}
+
+// Remove deletes a key without calling onEvict
+func (c *LRU[K, V]) Remove(key K) bool {
+	el, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.Remove(el)
+	delete(c.items, key)
+	return true
+}
+
+func (c *LRU[K, V]) evict(el *list.Element) {
+	e := el.Value.(*entry[K, V])
+	c.order.Remove(el)
+	delete(c.items, e.key)
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+func (c *LRU[K, V]) Len() int {
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) Capacity() int {
+	return c.capacity
+}
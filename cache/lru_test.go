@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestLRU(t *testing.T) {
+	evicted := []string{}
+	c := NewLRU(2, func(k string, v int) {
+		evicted = append(evicted, k)
+	})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	v, ok := c.Get("a")
+	test.AssertEqual(t, v, 1)
+	test.AssertEqual(t, ok, true)
+
+	// b is now the least recently used so it gets evicted
+	c.Put("c", 3)
+	test.AssertEqual(t, evicted, []string{"b"})
+	test.AssertEqual(t, c.Len(), 2)
+
+	_, ok = c.Get("b")
+	test.AssertEqual(t, ok, false)
+
+	// peeking doesn't count as a use
+	c.Peek("a")
+	c.Put("d", 4)
+	test.AssertEqual(t, evicted, []string{"b", "a"})
+
+	// updating an existing key doesn't evict anything
+	c.Put("d", 5)
+	v, _ = c.Get("d")
+	test.AssertEqual(t, v, 5)
+	test.AssertEqual(t, c.Len(), 2)
+
+	test.AssertEqual(t, c.Remove("c"), true)
+	test.AssertEqual(t, c.Remove("c"), false)
+	test.AssertEqual(t, c.Len(), 1)
+	test.AssertEqual(t, evicted, []string{"b", "a"})
+}
+
+func TestLRUZeroCapacity(t *testing.T) {
+	evicted := 0
+	c := NewLRU(0, func(k int, v int) { evicted++ })
+
+	c.Put(1, 1)
+	_, ok := c.Get(1)
+
+	test.AssertEqual(t, ok, false)
+	test.AssertEqual(t, evicted, 1)
+	test.AssertEqual(t, c.Len(), 0)
+	test.AssertEqual(t, c.Capacity(), 0)
+}
@@ -0,0 +1,91 @@
+package cycle
+
+// Cycle describes a sequence of states that repeats. After Mu steps from the start,
+// the states go round a loop of Lambda steps forever.
+type Cycle[S any] struct {
+	Mu     int
+	Lambda int
+	// States holds the first Mu+Lambda states, which is enough to find any later one
+	States []S
+}
+
+// At returns the state after n steps from the start
+func (c Cycle[S]) At(n int) S {
+	if n < len(c.States) {
+		return c.States[n]
+	}
+	return c.States[c.Mu+(n-c.Mu)%c.Lambda]
+}
+
+// Identity can be used as the key function when states are comparable themselves
+func Identity[S comparable](s S) S {
+	return s
+}
+
+// Detect steps from start until a state repeats, using key to tell states apart.
+// Every state is kept, which is needed to look them up later with At.
+// step must return a new state rather than modifying the one it is given.
+// If the sequence never repeats, this never returns.
+func Detect[S any, K comparable](start S, step func(s S) S, key func(s S) K) Cycle[S] {
+	seen := make(map[K]int)
+	states := []S{}
+
+	for curr := start; ; curr = step(curr) {
+		k := key(curr)
+		if i, ok := seen[k]; ok {
+			return Cycle[S]{Mu: i, Lambda: len(states) - i, States: states}
+		}
+		seen[k] = len(states)
+		states = append(states, curr)
+	}
+}
+
+// Brent finds where a sequence starts repeating and how long the loop is with Brent's algorithm.
+// Only two states are kept at a time, so it suits states that are expensive to store,
+// at the cost of stepping through the sequence more than once.
+func Brent[S any, K comparable](start S, step func(s S) S, key func(s S) K) (mu, lambda int) {
+	// find the loop length by moving the tortoise up to the hare at every power of two
+	power, lambda := 1, 1
+	tortoise, hare := start, step(start)
+	for key(tortoise) != key(hare) {
+		if power == lambda {
+			tortoise = hare
+			power *= 2
+			lambda = 0
+		}
+		hare = step(hare)
+		lambda++
+	}
+
+	// with the hare a loop ahead, they first meet where the loop starts
+	tortoise, hare = start, start
+	for i := 0; i < lambda; i++ {
+		hare = step(hare)
+	}
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		mu++
+	}
+
+	return mu, lambda
+}
+
+// Nth returns the state after n steps from start, skipping round the loop once the sequence repeats
+func Nth[S any, K comparable](start S, step func(s S) S, key func(s S) K, n int) S {
+	seen := make(map[K]int)
+	states := []S{}
+
+	for curr := start; ; curr = step(curr) {
+		if len(states) == n {
+			return curr
+		}
+
+		k := key(curr)
+		if i, ok := seen[k]; ok {
+			return Cycle[S]{Mu: i, Lambda: len(states) - i, States: states}.At(n)
+		}
+		seen[k] = len(states)
+		states = append(states, curr)
+	}
+}
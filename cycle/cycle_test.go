@@ -0,0 +1,69 @@
+package cycle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+// From Advent of Code 2017 Day 6: redistribute the fullest memory bank
+func redistribute(banks [4]int) [4]int {
+	most := 0
+	for i, v := range banks {
+		if v > banks[most] {
+			most = i
+		}
+	}
+
+	blocks := banks[most]
+	banks[most] = 0
+	for i := most + 1; blocks > 0; i++ {
+		banks[i%len(banks)]++
+		blocks--
+	}
+	return banks
+}
+
+func TestDetect(t *testing.T) {
+	c := Detect([4]int{0, 2, 7, 0}, redistribute, Identity[[4]int])
+
+	test.AssertEqual(t, c.Mu, 1)
+	test.AssertEqual(t, c.Lambda, 4)
+	test.AssertEqual(t, c.Mu+c.Lambda, 5)
+	test.AssertEqual(t, c.At(1), [4]int{2, 4, 1, 2})
+	test.AssertEqual(t, c.At(5), c.At(1))
+	test.AssertEqual(t, c.At(1000000000), c.At(4))
+}
+
+func TestBrent(t *testing.T) {
+	mu, lambda := Brent([4]int{0, 2, 7, 0}, redistribute, Identity[[4]int])
+	test.AssertEqual(t, mu, 1)
+	test.AssertEqual(t, lambda, 4)
+
+	// a sequence that loops straight away
+	mu, lambda = Brent(0, func(x int) int { return (x + 1) % 7 }, Identity[int])
+	test.AssertEqual(t, mu, 0)
+	test.AssertEqual(t, lambda, 7)
+
+	// agrees with Detect on a longer tail
+	step := func(x int) int { return (x*x + 1) % 1009 }
+	c := Detect(3, step, Identity[int])
+	mu, lambda = Brent(3, step, Identity[int])
+	test.AssertEqual(t, mu, c.Mu)
+	test.AssertEqual(t, lambda, c.Lambda)
+}
+
+func TestNth(t *testing.T) {
+	// states that aren't comparable need a key
+	rotate := func(s []string) []string {
+		return append(append([]string{}, s[1:]...), s[0])
+	}
+	key := func(s []string) string { return strings.Join(s, "") }
+
+	test.AssertEqual(t, Nth([]string{"a", "b", "c"}, rotate, key, 2), []string{"c", "a", "b"})
+	test.AssertEqual(t, Nth([]string{"a", "b", "c"}, rotate, key, 1000000000), []string{"b", "c", "a"})
+	test.AssertEqual(t, Nth([]string{"a", "b", "c"}, rotate, key, 0), []string{"a", "b", "c"})
+
+	test.AssertEqual(t, Nth([4]int{0, 2, 7, 0}, redistribute, Identity[[4]int], 1000000000), [4]int{1, 3, 4, 1})
+}
@@ -0,0 +1,301 @@
+package graphs
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+
+	"github.com/jack-barr3tt/gostuff/queue"
+)
+
+// Infinity is the distance reported between two nodes with no path between them
+const Infinity = math.MaxInt
+
+var ErrNegativeCycle = errors.New("graph contains a negative cycle")
+
+// DistanceMatrix holds the shortest distance between every pair of nodes.
+// Dist[i][j] is the distance from Nodes[i] to Nodes[j], or Infinity if there is no path.
+// Next[i][j] is the index of the node after Nodes[i] on that path, or -1 if there is no path.
+type DistanceMatrix struct {
+	Nodes []string
+	Dist  [][]int
+	Next  [][]int
+	index map[string]int
+}
+
+func newDistanceMatrix(names []string, index map[string]int) DistanceMatrix {
+	d := DistanceMatrix{Nodes: names, index: index, Dist: make([][]int, len(names)), Next: make([][]int, len(names))}
+	for i := range names {
+		d.Dist[i] = make([]int, len(names))
+		d.Next[i] = make([]int, len(names))
+		for j := range names {
+			d.Dist[i][j] = Infinity
+			d.Next[i][j] = -1
+		}
+	}
+	return d
+}
+
+// Index returns the row/column of a node in the matrix
+func (d DistanceMatrix) Index(name string) (int, bool) {
+	i, ok := d.index[name]
+	return i, ok
+}
+
+// Distance returns the shortest distance from one node to another.
+// Returns false if either node is unknown or there is no path.
+func (d DistanceMatrix) Distance(from, to string) (int, bool) {
+	i, okI := d.index[from]
+	j, okJ := d.index[to]
+	if !okI || !okJ || d.Dist[i][j] == Infinity {
+		return Infinity, false
+	}
+	return d.Dist[i][j], true
+}
+
+// Path reconstructs the shortest path from one node to another using the next-hop table.
+// Returns nil if there is no path.
+func (d DistanceMatrix) Path(from, to string) []string {
+	i, okI := d.index[from]
+	j, okJ := d.index[to]
+	if !okI || !okJ || d.Next[i][j] == -1 {
+		return nil
+	}
+
+	path := []string{from}
+	for i != j {
+		i = d.Next[i][j]
+		path = append(path, d.Nodes[i])
+	}
+	return path
+}
+
+// AllPairsShortestPaths returns the shortest distance between every pair of nodes.
+// Floyd-Warshall is used for dense graphs and Johnson's algorithm for sparse ones.
+// Negative edge costs are allowed, but an error matching ErrNegativeCycle is returned if a negative cycle exists.
+// The graph is indexed once and reused until nodes or edges are added, as described on Graph.
+func (g Graph) AllPairsShortestPaths() (DistanceMatrix, error) {
+	ig := g.indexAll()
+
+	edgeCount := 0
+	for _, edges := range ig.adj {
+		edgeCount += len(edges)
+	}
+
+	// Johnson's runs a Dijkstra per node, which only pays off when there are few edges
+	v := float64(len(ig.names))
+	if float64(edgeCount)*math.Log2(v+1) >= v*v {
+		return ig.floydWarshall()
+	}
+	return ig.johnson()
+}
+
+// FloydWarshall computes all pairs shortest paths in O(V^3)
+func (g Graph) FloydWarshall() (DistanceMatrix, error) {
+	return g.indexAll().floydWarshall()
+}
+
+// Johnson computes all pairs shortest paths by reweighting edges with Bellman-Ford
+// so that a Dijkstra search can be run from every node
+func (g Graph) Johnson() (DistanceMatrix, error) {
+	return g.indexAll().johnson()
+}
+
+// indexAll returns an indexed copy of every node. It is kept until nodes or edges are added,
+// so it must not be changed.
+func (g Graph) indexAll() indexedGraph {
+	return g.cachedIndex(false).forward
+}
+
+func (ig indexedGraph) floydWarshall() (DistanceMatrix, error) {
+	d := newDistanceMatrix(ig.names, ig.index)
+	n := len(ig.names)
+
+	for i := 0; i < n; i++ {
+		d.Dist[i][i] = 0
+		d.Next[i][i] = i
+		for _, e := range ig.adj[i] {
+			if e.cost < d.Dist[i][e.to] {
+				d.Dist[i][e.to] = e.cost
+				d.Next[i][e.to] = e.to
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if d.Dist[i][k] == Infinity {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if d.Dist[k][j] == Infinity {
+					continue
+				}
+				if newDist := d.Dist[i][k] + d.Dist[k][j]; newDist < d.Dist[i][j] {
+					d.Dist[i][j] = newDist
+					d.Next[i][j] = d.Next[i][k]
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if d.Dist[i][i] < 0 {
+			return DistanceMatrix{}, &NegativeCycleError{Cycle: ig.cycleFromNext(d.Next, i)}
+		}
+	}
+
+	return d, nil
+}
+
+// cycleFromNext follows the next-hop table from a node with a negative distance to itself.
+// Once a negative cycle exists the table can be left pointing around a different loop,
+// so if the hops don't make a negative cycle one is found with Bellman-Ford instead.
+func (ig indexedGraph) cycleFromNext(next [][]int, start int) []string {
+	cycle := []int{start}
+	onCycle := map[int]bool{start: true}
+	cost := 0
+	for curr := start; ; {
+		hop := next[curr][start]
+		edgeCost, ok := ig.edgeCost(curr, hop)
+		if hop == -1 || !ok {
+			break
+		}
+		cost += edgeCost
+		if hop == start {
+			if cost < 0 {
+				return ig.pathNames(cycle)
+			}
+			break
+		}
+		if onCycle[hop] {
+			break
+		}
+		onCycle[hop] = true
+		cycle = append(cycle, hop)
+		curr = hop
+	}
+
+	_, pred, cycleNode := ig.bellmanFord(make([]int, len(ig.names)))
+	return ig.cycleFrom(pred, cycleNode)
+}
+
+// edgeCost returns the cheapest edge from one node to another
+func (ig indexedGraph) edgeCost(from, to int) (int, bool) {
+	best, ok := Infinity, false
+	if to == -1 {
+		return best, ok
+	}
+	for _, e := range ig.adj[from] {
+		if e.to == to && e.cost < best {
+			best, ok = e.cost, true
+		}
+	}
+	return best, ok
+}
+
+func (ig indexedGraph) johnson() (DistanceMatrix, error) {
+	n := len(ig.names)
+
+	// Starting every node at 0 is the same as adding a virtual node with a 0 cost edge to every node
+	h, pred, cycleNode := ig.bellmanFord(make([]int, n))
+	if cycleNode != -1 {
+		return DistanceMatrix{}, &NegativeCycleError{Cycle: ig.cycleFrom(pred, cycleNode)}
+	}
+
+	reweighted := indexedGraph{names: ig.names, index: ig.index, adj: make([][]indexedEdge, n)}
+	for from, edges := range ig.adj {
+		for _, e := range edges {
+			reweighted.adj[from] = append(reweighted.adj[from], indexedEdge{to: e.to, cost: e.cost + h[from] - h[e.to]})
+		}
+	}
+
+	d := newDistanceMatrix(ig.names, ig.index)
+	for source := 0; source < n; source++ {
+		dist, next := reweighted.dijkstra(source)
+		for target := 0; target < n; target++ {
+			if dist[target] != Infinity {
+				d.Dist[source][target] = dist[target] - h[source] + h[target]
+				d.Next[source][target] = next[target]
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// bellmanFord relaxes every edge until the given starting distances settle.
+// Returns the distances, the predecessor of each node (or -1), and a node that
+// can still be relaxed because of a negative cycle (or -1 if there isn't one).
+func (ig indexedGraph) bellmanFord(dist []int) ([]int, []int, int) {
+	n := len(ig.names)
+	pred := make([]int, n)
+	for i := range pred {
+		pred[i] = -1
+	}
+
+	relax := func() int {
+		changed := -1
+		for from, edges := range ig.adj {
+			if dist[from] == Infinity {
+				continue
+			}
+			for _, e := range edges {
+				if newDist := dist[from] + e.cost; newDist < dist[e.to] {
+					dist[e.to] = newDist
+					pred[e.to] = from
+					changed = e.to
+				}
+			}
+		}
+		return changed
+	}
+
+	for i := 0; i < n; i++ {
+		if relax() == -1 {
+			return dist, pred, -1
+		}
+	}
+
+	return dist, pred, relax()
+}
+
+// dijkstra returns the distance from source to every node, and the first hop
+// on the way to each node (or -1 if unreachable). Edge costs must not be negative.
+func (ig indexedGraph) dijkstra(source int) ([]int, []int) {
+	n := len(ig.names)
+	dist := make([]int, n)
+	next := make([]int, n)
+	for i := range dist {
+		dist[i] = Infinity
+		next[i] = -1
+	}
+	dist[source] = 0
+	next[source] = source
+
+	pq := make(queue.PriorityQueue[int], 0)
+	heap.Init(&pq)
+	heap.Push(&pq, &queue.Item[int]{Value: source, Priority: 0})
+
+	for pq.Len() > 0 {
+		item := heap.Pop(&pq).(*queue.Item[int])
+		curr := item.Value
+		if item.Priority > dist[curr] {
+			continue
+		}
+
+		for _, e := range ig.adj[curr] {
+			if newDist := dist[curr] + e.cost; newDist < dist[e.to] {
+				dist[e.to] = newDist
+				if curr == source {
+					next[e.to] = e.to
+				} else {
+					next[e.to] = next[curr]
+				}
+				heap.Push(&pq, &queue.Item[int]{Value: e.to, Priority: newDist})
+			}
+		}
+	}
+
+	return dist, next
+}
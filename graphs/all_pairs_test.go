@@ -0,0 +1,80 @@
+package graphs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func textbookGraph() Graph {
+	// test case inspired by pearson edexcel a level decision mathematics 1 textbook ISBN 9781292183299 page 66
+	g, _ := NewGraph([]string{"S", "A", "B", "C", "D", "T"}, map[string][]Edge{
+		"S": {{Node: "A", Cost: 5}, {Node: "B", Cost: 6}, {Node: "C", Cost: 2}},
+		"A": {{Node: "S", Cost: 5}, {Node: "D", Cost: 4}},
+		"B": {{Node: "S", Cost: 6}, {Node: "D", Cost: 4}, {Node: "T", Cost: 8}, {Node: "C", Cost: 2}},
+		"C": {{Node: "S", Cost: 2}, {Node: "B", Cost: 2}, {Node: "T", Cost: 12}},
+		"D": {{Node: "A", Cost: 4}, {Node: "B", Cost: 4}, {Node: "T", Cost: 3}},
+		"T": {{Node: "B", Cost: 8}, {Node: "C", Cost: 12}, {Node: "D", Cost: 3}},
+	})
+	return g
+}
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	g := textbookGraph()
+
+	d, err := g.AllPairsShortestPaths()
+	test.AssertEqual(t, err, nil)
+
+	dist, ok := d.Distance("S", "T")
+	test.AssertEqual(t, ok, true)
+	test.AssertEqual(t, dist, 11)
+	test.AssertEqual(t, d.Path("S", "T"), []string{"S", "C", "B", "D", "T"})
+	test.AssertEqual(t, d.Path("A", "A"), []string{"A"})
+
+	dist, _ = d.Distance("S", "D")
+	test.AssertEqual(t, dist, 8)
+
+	dist, _ = d.Distance("A", "C")
+	test.AssertEqual(t, dist, 7)
+}
+
+func TestFloydWarshallAndJohnson(t *testing.T) {
+	g, _ := NewGraph([]string{"A", "B", "C", "D", "E"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 4}, {Node: "C", Cost: 2}},
+		"B": {{Node: "D", Cost: -3}},
+		"C": {{Node: "B", Cost: 1}, {Node: "D", Cost: 5}},
+		"D": {{Node: "A", Cost: 2}},
+	})
+
+	fw, err := g.FloydWarshall()
+	test.AssertEqual(t, err, nil)
+
+	j, err := g.Johnson()
+	test.AssertEqual(t, err, nil)
+
+	test.AssertEqual(t, fw.Dist, j.Dist)
+
+	dist, _ := fw.Distance("A", "D")
+	test.AssertEqual(t, dist, 0)
+	test.AssertEqual(t, fw.Path("A", "D"), []string{"A", "C", "B", "D"})
+	test.AssertEqual(t, j.Path("A", "D"), []string{"A", "C", "B", "D"})
+
+	// E is isolated
+	_, ok := fw.Distance("A", "E")
+	test.AssertEqual(t, ok, false)
+	test.AssertEqual(t, j.Path("A", "E") == nil, true)
+
+	// Test negative cycle detection
+	g.AddEdge("D", "B", 1)
+	g.AddEdge("B", "C", -2)
+
+	_, err = g.FloydWarshall()
+	test.AssertEqual(t, errors.Is(err, ErrNegativeCycle), true)
+	var cycleErr *NegativeCycleError
+	test.AssertEqual(t, errors.As(err, &cycleErr), true)
+	test.AssertEqual(t, cycleErr.Cycle, []string{"B", "C"})
+
+	_, err = g.Johnson()
+	test.AssertEqual(t, errors.Is(err, ErrNegativeCycle), true)
+}
@@ -0,0 +1,195 @@
+package graphs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NegativeCycleError is returned when shortest paths are undefined because of a negative cycle.
+// It matches ErrNegativeCycle when used with errors.Is.
+type NegativeCycleError struct {
+	Cycle []string
+}
+
+func (e *NegativeCycleError) Error() string {
+	if len(e.Cycle) == 0 {
+		return ErrNegativeCycle.Error()
+	}
+	return fmt.Sprintf("%s: %s -> %s", ErrNegativeCycle, strings.Join(e.Cycle, " -> "), e.Cycle[0])
+}
+
+func (e *NegativeCycleError) Is(target error) bool {
+	return target == ErrNegativeCycle
+}
+
+// HasNegativeEdges reports whether any edge added to the graph has a negative cost.
+// It is kept up to date as edges are added, so it doesn't have to look at every edge,
+// but edges changed directly through a node's Adj aren't seen.
+func (g Graph) HasNegativeEdges() bool {
+	return g.state != nil && g.state.negativeEdges
+}
+
+// BellmanFord returns the shortest path from source to target, allowing negative edge costs.
+// Returns a *NegativeCycleError if a negative cycle is reachable from source.
+// Returns nil, -1 if source or target don't exist, or if no path exists.
+func (g Graph) BellmanFord(source, target string) ([]string, int, error) {
+	if _, ok := g.At(source); !ok {
+		return nil, -1, nil
+	}
+
+	ig := g.indexReachable(source, nil)
+	dist, pred, cycleNode := ig.bellmanFord(startDist(len(ig.names), ig.index[source]))
+	if cycleNode != -1 {
+		return nil, -1, &NegativeCycleError{Cycle: ig.cycleFrom(pred, cycleNode)}
+	}
+
+	return ig.predPath(dist, pred, target)
+}
+
+// SPFA (shortest path faster algorithm) is a queue based Bellman-Ford that only
+// relaxes edges out of nodes whose distance has just changed. It is usually much
+// faster than BellmanFord but has the same worst case and the same results.
+func (g Graph) SPFA(source, target string) ([]string, int, error) {
+	if _, ok := g.At(source); !ok {
+		return nil, -1, nil
+	}
+	return g.indexReachable(source, nil).spfa(source, target, nil, nil)
+}
+
+// indexReachable indexes every node reachable from source, skipping nodes rejected by allow
+func (g Graph) indexReachable(source string, allow func(n Node) bool) indexedGraph {
+	names := []string{}
+	for _, name := range g.Connected(source) {
+		if n, _ := g.At(name); name == source || allow == nil || allow(*n) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return g.indexNodes(names)
+}
+
+// spfa runs SPFA from source. If tr is given it is told about every node taken off the
+// queue, using at to look the node up, and every relaxation, and it can stop the search or cut paths short.
+func (ig indexedGraph) spfa(source, target string, tr *tracker, at func(name string) Node) ([]string, int, error) {
+	n := len(ig.names)
+	start := ig.index[source]
+	dist := startDist(n, start)
+	pred := make([]int, n)
+	length := make([]int, n)
+	inQueue := make([]bool, n)
+	for i := range pred {
+		pred[i] = -1
+	}
+
+	queue := []int{start}
+	inQueue[start] = true
+	expanded := make([]bool, n)
+	if tr != nil {
+		tr.push(len(queue))
+	}
+
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		inQueue[curr] = false
+
+		if tr != nil {
+			if err := tr.expand(at(ig.names[curr]), dist[curr]); err != nil {
+				return nil, -1, err
+			}
+			if expanded[curr] {
+				tr.stats.Reopened++
+			}
+			expanded[curr] = true
+		}
+
+		for _, e := range ig.adj[curr] {
+			if newDist := dist[curr] + e.cost; newDist < dist[e.to] {
+				if tr != nil && !tr.allow(length[curr]+1, newDist) {
+					continue
+				}
+				dist[e.to] = newDist
+				pred[e.to] = curr
+				length[e.to] = length[curr] + 1
+
+				// A shortest path can't use more than n-1 edges without repeating a node
+				if length[e.to] >= n {
+					cycle := ig.cycleFrom(pred, e.to)
+					if cycle == nil {
+						// The predecessors have not closed into a loop yet, so let a full Bellman-Ford find it
+						_, bfPred, bfNode := ig.bellmanFord(startDist(n, start))
+						cycle = ig.cycleFrom(bfPred, bfNode)
+					}
+					return nil, -1, &NegativeCycleError{Cycle: cycle}
+				}
+
+				if tr != nil {
+					tr.relax(ig.names[curr], ig.names[e.to], newDist)
+				}
+
+				if !inQueue[e.to] {
+					queue = append(queue, e.to)
+					inQueue[e.to] = true
+					if tr != nil {
+						tr.push(len(queue))
+					}
+				}
+			}
+		}
+	}
+
+	path, cost, err := ig.predPath(dist, pred, target)
+	if path == nil && tr != nil {
+		return nil, -1, tr.pruned
+	}
+	return path, cost, err
+}
+
+func (ig indexedGraph) predPath(dist, pred []int, target string) ([]string, int, error) {
+	end, ok := ig.index[target]
+	if !ok || dist[end] == Infinity {
+		return nil, -1, nil
+	}
+
+	path := []int{end}
+	for curr := pred[end]; curr != -1; curr = pred[curr] {
+		path = append([]int{curr}, path...)
+	}
+
+	return ig.pathNames(path), dist[end], nil
+}
+
+func startDist(n, source int) []int {
+	dist := make([]int, n)
+	for i := range dist {
+		dist[i] = Infinity
+	}
+	dist[source] = 0
+	return dist
+}
+
+// cycleFrom follows the predecessors of a node until one repeats, and returns the
+// nodes of that loop in path order. Any loop in the predecessors found while
+// relaxing edges is a negative cycle. Returns nil if the predecessors don't loop.
+func (ig indexedGraph) cycleFrom(pred []int, node int) []string {
+	if node == -1 {
+		return nil
+	}
+
+	seen := make([]bool, len(ig.names))
+	for !seen[node] {
+		seen[node] = true
+		if pred[node] == -1 {
+			return nil
+		}
+		node = pred[node]
+	}
+
+	cycle := []int{node}
+	for curr := pred[node]; curr != node; curr = pred[curr] {
+		cycle = append([]int{curr}, cycle...)
+	}
+
+	return ig.pathNames(cycle)
+}
@@ -0,0 +1,89 @@
+package graphs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func negativeGraph() Graph {
+	g, _ := NewGraph([]string{"A", "B", "C", "D", "E"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 4}, {Node: "C", Cost: 2}},
+		"B": {{Node: "D", Cost: -3}},
+		"C": {{Node: "B", Cost: 1}, {Node: "D", Cost: 5}},
+		"D": {{Node: "A", Cost: 2}},
+	})
+	return g
+}
+
+func TestHasNegativeEdges(t *testing.T) {
+	test.AssertEqual(t, negativeGraph().HasNegativeEdges(), true)
+	test.AssertEqual(t, textbookGraph().HasNegativeEdges(), false)
+
+	// the flag follows edges added later, including through copies of the graph
+	g := textbookGraph()
+	copied := g
+	g.AddEdge("T", "S", -1)
+	test.AssertEqual(t, copied.HasNegativeEdges(), true)
+
+	g = NewEmptyGraph()
+	g.AddNode("A", []Edge{{Node: "B", Cost: -2}})
+	test.AssertEqual(t, g.HasNegativeEdges(), true)
+}
+
+func TestBellmanFord(t *testing.T) {
+	g := negativeGraph()
+
+	for _, search := range []func(string, string) ([]string, int, error){g.BellmanFord, g.SPFA} {
+		path, length, err := search("A", "D")
+		test.AssertEqual(t, err, nil)
+		test.AssertEqual(t, path, []string{"A", "C", "B", "D"})
+		test.AssertEqual(t, length, 0)
+
+		// Test unreachable and non-existent nodes
+		path, length, err = search("A", "E")
+		test.AssertEqual(t, err, nil)
+		test.AssertEqual(t, path == nil, true)
+		test.AssertEqual(t, length, -1)
+
+		_, length, _ = search("nonexistent", "A")
+		test.AssertEqual(t, length, -1)
+	}
+
+	// Test negative cycle reporting
+	g.AddEdge("B", "C", -2)
+
+	for _, search := range []func(string, string) ([]string, int, error){g.BellmanFord, g.SPFA} {
+		_, _, err := search("A", "D")
+		test.AssertEqual(t, errors.Is(err, ErrNegativeCycle), true)
+
+		var cycleErr *NegativeCycleError
+		test.AssertEqual(t, errors.As(err, &cycleErr), true)
+		test.AssertSlicesEqual(t, cycleErr.Cycle, []string{"B", "C"})
+	}
+}
+
+func TestShortestPathNegativeEdges(t *testing.T) {
+	g := negativeGraph()
+
+	path, length := g.ShortestPath("A", "D", func(n Node) int { return 0 })
+	test.AssertEqual(t, path, []string{"A", "C", "B", "D"})
+	test.AssertEqual(t, length, 0)
+
+	// the heuristic can still rule nodes out
+	path, length = g.ShortestPath("A", "D", func(n Node) int {
+		if n.Name == "C" {
+			return -1
+		}
+		return 0
+	})
+	test.AssertEqual(t, path, []string{"A", "B", "D"})
+	test.AssertEqual(t, length, 1)
+
+	g.AddEdge("B", "C", -2)
+
+	path, length = g.ShortestPath("A", "D", func(n Node) int { return 0 })
+	test.AssertEqual(t, path == nil, true)
+	test.AssertEqual(t, length, -1)
+}
@@ -0,0 +1,186 @@
+package graphs
+
+import (
+	"container/heap"
+
+	"github.com/jack-barr3tt/gostuff/queue"
+)
+
+// reversed returns a copy of the indexed graph with every edge flipped
+func (ig indexedGraph) reversed() indexedGraph {
+	rev := indexedGraph{names: ig.names, index: ig.index, adj: make([][]indexedEdge, len(ig.names))}
+	for from, edges := range ig.adj {
+		for _, e := range edges {
+			rev.adj[e.to] = append(rev.adj[e.to], indexedEdge{to: from, cost: e.cost})
+		}
+	}
+	return rev
+}
+
+// joinAt builds the path source -> meet -> target from the predecessors of both searches
+func (ig indexedGraph) joinAt(meet int, forwardPred, backwardPred []int) []string {
+	path := []int{meet}
+	for curr := forwardPred[meet]; curr != -1; curr = forwardPred[curr] {
+		path = append([]int{curr}, path...)
+	}
+	for curr := backwardPred[meet]; curr != -1; curr = backwardPred[curr] {
+		path = append(path, curr)
+	}
+	return ig.pathNames(path)
+}
+
+// searchSide is the state of one direction of a bidirectional search
+type searchSide struct {
+	adj  [][]indexedEdge
+	dist []int
+	pred []int
+}
+
+func newSearchSide(adj [][]indexedEdge, start int) *searchSide {
+	side := &searchSide{adj: adj, dist: startDist(len(adj), start), pred: make([]int, len(adj))}
+	for i := range side.pred {
+		side.pred[i] = -1
+	}
+	return side
+}
+
+type dijkstraSide struct {
+	*searchSide
+	pq queue.PriorityQueue[int]
+}
+
+func newDijkstraSide(adj [][]indexedEdge, start int) *dijkstraSide {
+	side := &dijkstraSide{searchSide: newSearchSide(adj, start)}
+	heap.Init(&side.pq)
+	heap.Push(&side.pq, &queue.Item[int]{Value: start, Priority: 0})
+	return side
+}
+
+// top returns the smallest distance still waiting in the queue
+func (s *dijkstraSide) top() int {
+	for s.pq.Len() > 0 && s.pq[0].Priority > s.dist[s.pq[0].Value] {
+		heap.Pop(&s.pq)
+	}
+	if s.pq.Len() == 0 {
+		return Infinity
+	}
+	return s.pq[0].Priority
+}
+
+// BidirectionalShortestPath returns the shortest path from source to target by running
+// Dijkstra forwards from source and backwards from target until the two searches meet.
+// Edge costs must not be negative. Only nodes already in the graph are searched, so
+// this is meant for explicit graphs rather than virtual ones. The reversed graph used by
+// the backward search is built once and kept until nodes or edges are added, so edges
+// changed directly through a node's Adj aren't seen.
+// Returns nil, -1 if source or target don't exist, or if no path exists.
+func (g Graph) BidirectionalShortestPath(source, target string) ([]string, int) {
+	ic := g.cachedIndex(true)
+	ig := ic.forward
+	start, okS := ig.index[source]
+	end, okT := ig.index[target]
+	if !okS || !okT {
+		return nil, -1
+	}
+
+	forward := newDijkstraSide(ig.adj, start)
+	backward := newDijkstraSide(ic.reverse.adj, end)
+
+	best := Infinity
+	meet := -1
+	if start == end {
+		best, meet = 0, start
+	}
+
+	for {
+		topF, topB := forward.top(), backward.top()
+		if topF == Infinity || topB == Infinity || topF+topB >= best {
+			break
+		}
+
+		side, other := forward, backward
+		if topB < topF {
+			side, other = backward, forward
+		}
+
+		curr := heap.Pop(&side.pq).(*queue.Item[int]).Value
+		for _, e := range side.adj[curr] {
+			newDist := side.dist[curr] + e.cost
+			if newDist >= side.dist[e.to] {
+				continue
+			}
+			side.dist[e.to] = newDist
+			side.pred[e.to] = curr
+			heap.Push(&side.pq, &queue.Item[int]{Value: e.to, Priority: newDist})
+
+			if other.dist[e.to] != Infinity && newDist+other.dist[e.to] < best {
+				best = newDist + other.dist[e.to]
+				meet = e.to
+			}
+		}
+	}
+
+	if meet == -1 {
+		return nil, -1
+	}
+
+	return ig.joinAt(meet, forward.pred, backward.pred), best
+}
+
+// BidirectionalBFS returns the path from source to target with the fewest edges, ignoring
+// edge costs, by growing breadth first frontiers from both ends. The returned length is
+// the number of edges. Like BidirectionalShortestPath this is meant for explicit graphs.
+// Returns nil, -1 if source or target don't exist, or if no path exists.
+func (g Graph) BidirectionalBFS(source, target string) ([]string, int) {
+	ic := g.cachedIndex(true)
+	ig := ic.forward
+	start, okS := ig.index[source]
+	end, okT := ig.index[target]
+	if !okS || !okT {
+		return nil, -1
+	}
+	if start == end {
+		return []string{source}, 0
+	}
+
+	forward := newSearchSide(ig.adj, start)
+	backward := newSearchSide(ic.reverse.adj, end)
+	frontiers := [2][]int{{start}, {end}}
+	sides := [2]*searchSide{forward, backward}
+
+	for len(frontiers[0]) > 0 && len(frontiers[1]) > 0 {
+		// grow whichever frontier is smaller, a whole layer at a time
+		i := 0
+		if len(frontiers[1]) < len(frontiers[0]) {
+			i = 1
+		}
+		side, other := sides[i], sides[1-i]
+
+		best := Infinity
+		meet := -1
+		next := []int{}
+
+		for _, curr := range frontiers[i] {
+			for _, e := range side.adj[curr] {
+				if side.dist[e.to] != Infinity {
+					continue
+				}
+				side.dist[e.to] = side.dist[curr] + 1
+				side.pred[e.to] = curr
+				next = append(next, e.to)
+
+				if other.dist[e.to] != Infinity && side.dist[e.to]+other.dist[e.to] < best {
+					best = side.dist[e.to] + other.dist[e.to]
+					meet = e.to
+				}
+			}
+		}
+
+		if meet != -1 {
+			return ig.joinAt(meet, forward.pred, backward.pred), best
+		}
+		frontiers[i] = next
+	}
+
+	return nil, -1
+}
@@ -0,0 +1,161 @@
+package graphs
+
+import (
+	"github.com/jack-barr3tt/gostuff/set"
+)
+
+// The algorithms in this file treat the graph as undirected: two nodes are
+// neighbours if there is an edge between them in either direction. Self loops are ignored.
+
+func (g Graph) undirectedNeighbours() map[string]set.Set[string] {
+	neighbours := make(map[string]set.Set[string])
+	for _, name := range g.GetNodesSorted() {
+		neighbours[name] = set.New[string]()
+	}
+	for name, n := range g.nodeIds {
+		for _, edge := range n.Adj {
+			if _, known := neighbours[edge.Node]; known && edge.Node != name {
+				neighbours[name].Add(edge.Node)
+				neighbours[edge.Node].Add(name)
+			}
+		}
+	}
+	return neighbours
+}
+
+// MaximalCliques returns every clique that can't be extended by another node,
+// using the Bron-Kerbosch algorithm with pivoting
+func (g Graph) MaximalCliques() []set.Set[string] {
+	neighbours := g.undirectedNeighbours()
+	cliques := []set.Set[string]{}
+
+	var bronKerbosch func(r, p, x set.Set[string])
+	bronKerbosch = func(r, p, x set.Set[string]) {
+		if p.Size() == 0 {
+			if x.Size() == 0 {
+				cliques = append(cliques, r)
+			}
+			return
+		}
+
+		// any maximal clique contains the pivot or one of its non-neighbours,
+		// so picking the pivot with the most neighbours in p skips the most branches
+		pivot, best := "", -1
+		for _, u := range set.Sorted(p.Union(x)) {
+			if count := p.Intersection(neighbours[u]).Size(); count > best {
+				pivot, best = u, count
+			}
+		}
+
+		for _, v := range set.Sorted(p.Difference(neighbours[pivot])) {
+			bronKerbosch(r.Union(set.FromSlice([]string{v})), p.Intersection(neighbours[v]), x.Intersection(neighbours[v]))
+			p.Remove(v)
+			x.Add(v)
+		}
+	}
+
+	bronKerbosch(set.New[string](), set.FromSlice(g.GetNodes()), set.New[string]())
+	return cliques
+}
+
+// MaximumClique returns the largest clique in the graph
+func (g Graph) MaximumClique() set.Set[string] {
+	best := set.New[string]()
+	for _, clique := range g.MaximalCliques() {
+		if clique.Size() > best.Size() {
+			best = clique
+		}
+	}
+	return best
+}
+
+// Triangles returns every set of three nodes that are all neighbours of each other
+func (g Graph) Triangles() []set.Set[string] {
+	neighbours := g.undirectedNeighbours()
+	triangles := []set.Set[string]{}
+
+	for _, a := range g.GetNodesSorted() {
+		for _, b := range set.Sorted(neighbours[a]) {
+			if b <= a {
+				continue
+			}
+			for _, c := range set.Sorted(neighbours[b]) {
+				if c > b && neighbours[a].Has(c) {
+					triangles = append(triangles, set.FromSlice([]string{a, b, c}))
+				}
+			}
+		}
+	}
+
+	return triangles
+}
+
+// GreedyColouring gives each node, in name order, the lowest colour none of its neighbours have.
+// Colours are numbered from 0. Returns the colour of each node and the number of colours used.
+func (g Graph) GreedyColouring() (map[string]int, int) {
+	neighbours := g.undirectedNeighbours()
+	colours := make(map[string]int)
+	count := 0
+
+	for _, name := range g.GetNodesSorted() {
+		colours[name] = lowestFreeColour(neighbours[name], colours)
+		if colours[name]+1 > count {
+			count = colours[name] + 1
+		}
+	}
+
+	return colours, count
+}
+
+// DSaturColouring colours nodes greedily, always picking next the node whose neighbours
+// already use the most distinct colours, which usually needs fewer colours than GreedyColouring.
+// Ties are broken by degree and then by name. Returns the colour of each node and the number of colours used.
+func (g Graph) DSaturColouring() (map[string]int, int) {
+	neighbours := g.undirectedNeighbours()
+	names := g.GetNodesSorted()
+	colours := make(map[string]int)
+	count := 0
+
+	for len(colours) < len(names) {
+		next, bestSaturation, bestDegree := "", -1, -1
+		for _, name := range names {
+			if _, done := colours[name]; done {
+				continue
+			}
+
+			used := set.New[int]()
+			for _, n := range neighbours[name].ToSlice() {
+				if c, ok := colours[n]; ok {
+					used.Add(c)
+				}
+			}
+
+			saturation, degree := used.Size(), neighbours[name].Size()
+			if saturation > bestSaturation || (saturation == bestSaturation && degree > bestDegree) {
+				next, bestSaturation, bestDegree = name, saturation, degree
+			}
+		}
+
+		colours[next] = lowestFreeColour(neighbours[next], colours)
+		if colours[next]+1 > count {
+			count = colours[next] + 1
+		}
+	}
+
+	return colours, count
+}
+
+func lowestFreeColour(neighbours set.Set[string], colours map[string]int) int {
+	used := set.New[int]()
+	for _, n := range neighbours.ToSlice() {
+		if c, ok := colours[n]; ok {
+			used.Add(c)
+		}
+	}
+
+	colour := 0
+	for used.Has(colour) {
+		colour++
+	}
+	return colour
+}
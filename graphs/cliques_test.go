@@ -0,0 +1,135 @@
+package graphs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/set"
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+// From Advent of Code 2024 Day 23 example
+const lanParty = `kh-tc
+qp-kh
+de-cg
+ka-co
+yn-aq
+qp-ub
+cg-tb
+vc-aq
+tb-ka
+wh-tc
+yn-cg
+kh-ub
+ta-co
+de-co
+tc-td
+tb-wq
+wh-td
+ta-ka
+td-qp
+aq-cg
+wq-ub
+ub-vc
+de-ta
+wq-aq
+wq-vc
+wh-yn
+ka-de
+kh-ta
+co-tc
+wh-qp
+tb-vc
+td-yn`
+
+func lanPartyGraph() Graph {
+	g := NewEmptyGraph()
+	for _, line := range strings.Split(lanParty, "\n") {
+		ends := strings.Split(line, "-")
+		g.AddNode(ends[0], nil)
+		g.AddNode(ends[1], nil)
+		g.AddEdge(ends[0], ends[1], 1)
+	}
+	return g
+}
+
+func TestTriangles(t *testing.T) {
+	triangles := lanPartyGraph().Triangles()
+
+	test.AssertEqual(t, len(triangles), 12)
+	test.AssertEqual(t, set.Sorted(triangles[0]), []string{"aq", "cg", "yn"})
+
+	withT := 0
+	for _, tri := range triangles {
+		for _, name := range tri.ToSlice() {
+			if strings.HasPrefix(name, "t") {
+				withT++
+				break
+			}
+		}
+	}
+	test.AssertEqual(t, withT, 7)
+}
+
+func TestMaximalCliques(t *testing.T) {
+	g := lanPartyGraph()
+
+	cliques := g.MaximalCliques()
+	for _, clique := range cliques {
+		// every pair in a clique must be connected
+		members := clique.ToSlice()
+		for i := range members {
+			for j := i + 1; j < len(members); j++ {
+				test.AssertEqual(t, g.undirectedNeighbours()[members[i]].Has(members[j]), true)
+			}
+		}
+	}
+
+	test.AssertEqual(t, set.Sorted(g.MaximumClique()), []string{"co", "de", "ka", "ta"})
+
+	g2, _ := NewGraph([]string{"A", "B", "C", "D"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}, {Node: "C", Cost: 1}},
+		"B": {{Node: "C", Cost: 1}},
+		"C": {{Node: "D", Cost: 1}},
+	})
+
+	cliques2 := [][]string{}
+	for _, c := range g2.MaximalCliques() {
+		cliques2 = append(cliques2, set.Sorted(c))
+	}
+	test.AssertSlicesEqual(t, cliques2, [][]string{{"A", "B", "C"}, {"C", "D"}})
+
+	test.AssertEqual(t, NewEmptyGraph().MaximumClique().Size(), 0)
+}
+
+func TestColouring(t *testing.T) {
+	g := lanPartyGraph()
+	neighbours := g.undirectedNeighbours()
+
+	for _, colour := range []func() (map[string]int, int){g.GreedyColouring, g.DSaturColouring} {
+		colours, count := colour()
+
+		test.AssertEqual(t, len(colours), len(g.GetNodes()))
+		for name, c := range colours {
+			test.AssertEqual(t, c < count, true)
+			for _, n := range neighbours[name].ToSlice() {
+				test.AssertNotEqual(t, colours[n], c)
+			}
+		}
+
+		// the biggest clique needs a colour for each member
+		test.AssertEqual(t, count >= 4, true)
+	}
+
+	// the path A-C-D-B is 2 colourable but greedy in name order needs 3
+	path, _ := NewGraph([]string{"A", "B", "C", "D"}, map[string][]Edge{
+		"A": {{Node: "C", Cost: 1}},
+		"C": {{Node: "D", Cost: 1}},
+		"D": {{Node: "B", Cost: 1}},
+	})
+
+	_, greedyCount := path.GreedyColouring()
+	_, dsaturCount := path.DSaturColouring()
+	test.AssertEqual(t, greedyCount, 3)
+	test.AssertEqual(t, dsaturCount, 2)
+}
@@ -2,37 +2,123 @@ package graphs
 
 import (
 	"container/heap"
+	"context"
 	"fmt"
+	"slices"
 	"sort"
-	"strings"
 
+	"github.com/jack-barr3tt/gostuff/cache"
 	"github.com/jack-barr3tt/gostuff/maps"
 	"github.com/jack-barr3tt/gostuff/queue"
-	"github.com/jack-barr3tt/gostuff/slices"
 )
 
 type Edge struct {
 	Node string
 	Cost int
+	// Data is attached to Node when a virtual graph first creates it from this edge
+	Data any
 }
 
 type Node struct {
 	Name string
 	Adj  []Edge
+	// Data is an optional payload, so state doesn't have to be encoded into Name
+	Data any
+
+	// expanded is set while the node's generated edges are held in Adj
+	expanded bool
+	// generated is set once the generator has been run for the node, even if Adj wasn't kept
+	generated bool
+}
+
+// Payload returns the data attached to a node as type T
+func Payload[T any](n Node) (T, bool) {
+	data, ok := n.Data.(T)
+	return data, ok
+}
+
+// CachePolicy controls how many generated adjacency lists a virtual graph keeps.
+// Node names and payloads are always kept so any node that has been seen can be looked up again.
+type CachePolicy int
+
+const (
+	// CacheUnbounded keeps every generated adjacency list forever
+	CacheUnbounded CachePolicy = iota
+	// CacheLRU keeps the adjacency lists of the CacheSize most recently used nodes
+	CacheLRU
+	// CacheNone runs the generator every time a node is looked up
+	CacheNone
+)
+
+// VirtualGraphOptions configures a virtual graph. The zero value keeps every expansion.
+type VirtualGraphOptions struct {
+	// Cache is how many generated adjacency lists are kept
+	Cache CachePolicy
+	// CacheSize is how many adjacency lists CacheLRU keeps. Sizes below 1 are treated as 1.
+	CacheSize int
+	// OriginData is the payload of the origin node
+	OriginData any
 }
 
+// Graph is a directed graph with weighted edges, either built up front or generated as it is explored.
+// Whole-graph indexes used by algorithms such as AllPairsShortestPaths are kept between calls and only
+// rebuilt when nodes or edges are added with AddNode, AddEdge or the generator, so edges changed directly
+// through a node's Adj aren't seen by them. A Graph is not safe for concurrent use.
 type Graph struct {
-	nodeIds map[string]*Node
-	gen     func(n *Node) []Edge
+	nodeIds    map[string]*Node
+	gen        func(n *Node) []Edge
+	cache      CachePolicy
+	expansions *cache.LRU[string, *Node]
+	state      *graphState
+}
+
+// graphState is shared by every copy of a Graph, so facts about its edges can be kept as they are added
+type graphState struct {
+	// negativeEdges is set once an edge with a negative cost has been added
+	negativeEdges bool
+	// version goes up whenever nodes or edges are added, so cached indexes know when they are stale
+	version int
+	indexed *indexCache
+}
+
+// noteEdges records anything worth knowing about nodes or edges that have just been added
+func (g Graph) noteEdges(edges []Edge) {
+	if g.state == nil {
+		return
+	}
+	g.state.version++
+	for _, edge := range edges {
+		if edge.Cost < 0 {
+			g.state.negativeEdges = true
+		}
+	}
 }
 
 func NewVirtualGraph(nodeGenerator func(n *Node) []Edge, origin string) Graph {
-	node := &Node{Name: origin}
+	return NewVirtualGraphWithOptions(nodeGenerator, origin, VirtualGraphOptions{})
+}
+
+// NewVirtualGraphWithOptions is NewVirtualGraph with control over how expansions are cached
+// and a payload for the origin node
+func NewVirtualGraphWithOptions(nodeGenerator func(n *Node) []Edge, origin string, opts VirtualGraphOptions) Graph {
+	node := &Node{Name: origin, Data: opts.OriginData}
 
 	nodeIds := make(map[string]*Node)
 	nodeIds[origin] = node
 
-	return Graph{nodeIds: nodeIds, gen: nodeGenerator}
+	g := Graph{nodeIds: nodeIds, gen: nodeGenerator, cache: opts.Cache, state: &graphState{}}
+	if g.cache == CacheLRU {
+		size := opts.CacheSize
+		if size < 1 {
+			size = 1
+		}
+		g.expansions = cache.NewLRU(size, func(name string, n *Node) {
+			n.Adj = nil
+			n.expanded = false
+		})
+	}
+
+	return g
 }
 
 func NewGraph(nodes []string, edges map[string][]Edge) (Graph, error) {
@@ -52,16 +138,21 @@ func NewGraph(nodes []string, edges map[string][]Edge) (Graph, error) {
 		}
 	}
 
-	return Graph{nodeIds: nodeIds}, nil
+	g := Graph{nodeIds: nodeIds, state: &graphState{}}
+	for _, nodeEdges := range edges {
+		g.noteEdges(nodeEdges)
+	}
+	return g, nil
 }
 
 func NewEmptyGraph() Graph {
-	return Graph{nodeIds: make(map[string]*Node)}
+	return Graph{nodeIds: make(map[string]*Node), state: &graphState{}}
 }
 
 func (g Graph) AddNode(name string, edges []Edge) {
 	if _, exists := g.nodeIds[name]; !exists {
 		g.nodeIds[name] = &Node{Name: name, Adj: edges}
+		g.noteEdges(edges)
 	}
 }
 
@@ -74,94 +165,58 @@ func (g Graph) AddEdge(from, to string, cost int) error {
 	}
 
 	fromNode.Adj = append(fromNode.Adj, Edge{Node: to, Cost: cost})
+	g.noteEdges(fromNode.Adj[len(fromNode.Adj)-1:])
 	return nil
 }
 
+// At returns a node, expanding it first if the graph is virtual.
+// Add edges with AddEdge rather than through the node's Adj, or cached indexes will go stale.
 func (g Graph) At(name string) (*Node, bool) {
 	n, ok := g.nodeIds[name]
-	if g.gen == nil {
+	if g.gen == nil || !ok {
 		return n, ok
 	}
-	if ok && len(n.Adj) == 0 {
-		n.Adj = g.gen(n)
-		for _, edge := range n.Adj {
-			if _, ok := g.nodeIds[edge.Node]; !ok {
-				g.nodeIds[edge.Node] = &Node{Name: edge.Node}
-			}
-		}
-	}
-	return n, ok
-}
 
-// ShortestPath returns the shortest path from source to target using the A* algorithm.
-// The heuristic function should return -1 if the node is not reachable.
-// The heuristic function should return lower values for nodes that are more favorable.
-func (g Graph) ShortestPath(source, target string, heuristic func(n Node) int) ([]string, int) {
-	if _, ok := g.At(source); !ok {
-		return nil, -1
-	}
-
-	pq := make(queue.PriorityQueue[string], 0)
-	heap.Init(&pq)
-	heap.Push(&pq, &queue.Item[string]{Value: source, Priority: 0})
-
-	cameFrom := make(map[string]string)
-	costSoFar := make(map[string]int)
-	costSoFar[source] = 0
-
-	costRemaining := make(map[string]int)
-	costRemaining[source] = heuristic(*g.nodeIds[source])
-
-	for pq.Len() > 0 {
-		curr := heap.Pop(&pq).(*queue.Item[string]).Value
-		if curr == target {
-			return g.reconstructPath(cameFrom, target)
+	if n.expanded {
+		if g.expansions != nil {
+			g.expansions.Get(name)
 		}
+		return n, ok
+	}
 
-		currNode, _ := g.At(curr)
-		for _, edge := range currNode.Adj {
-			newCost := costSoFar[curr] + edge.Cost
-			if _, ok := costSoFar[edge.Node]; (!ok || newCost < costSoFar[edge.Node]) && heuristic(*g.nodeIds[edge.Node]) != -1 {
-				cameFrom[edge.Node] = curr
-				costSoFar[edge.Node] = newCost
-				costRemaining[edge.Node] = newCost + heuristic(*g.nodeIds[edge.Node])
-				if !pq.Has(edge.Node) {
-					item := &queue.Item[string]{Value: edge.Node, Priority: costRemaining[edge.Node]}
-					heap.Push(&pq, item)
-				}
-			}
+	adj := g.gen(n)
+	n.generated = true
+	g.noteEdges(adj)
+	for _, edge := range adj {
+		if _, ok := g.nodeIds[edge.Node]; !ok {
+			g.nodeIds[edge.Node] = &Node{Name: edge.Node, Data: edge.Data}
 		}
 	}
 
-	return nil, -1
-}
-
-func (g Graph) reconstructPath(cameFrom map[string]string, current string) ([]string, int) {
-	totalPath := []string{current}
-	ok := true
-	for {
-		current, ok = cameFrom[current]
-		if !ok {
-			break
-		}
-		totalPath = append([]string{current}, totalPath...)
+	if g.cache == CacheNone {
+		return &Node{Name: n.Name, Adj: adj, Data: n.Data}, ok
 	}
 
-	cost := 0
-	for i := 0; i < len(totalPath)-1; i++ {
-		currNode, _ := g.At(totalPath[i])
-		for _, edge := range currNode.Adj {
-			if edge.Node == totalPath[i+1] {
-				cost += edge.Cost
-				break
-			}
-		}
+	n.Adj = adj
+	n.expanded = true
+	if g.expansions != nil {
+		g.expansions.Put(name, n)
 	}
+	return n, ok
+}
 
-	return totalPath, cost
+// ShortestPath returns the shortest path from source to target using the A* algorithm.
+// The heuristic function should return -1 if the node is not reachable.
+// The heuristic function should return lower values for nodes that are more favorable.
+// If any edge cost of an explicit graph is negative, SPFA is used instead and the heuristic is only used
+// to exclude nodes. Virtual graphs are still searched with A*, which stops when it runs into a negative cycle.
+// Returns nil, -1 if no path exists or a negative cycle makes the shortest path undefined.
+func (g Graph) ShortestPath(source, target string, heuristic func(n Node) int) ([]string, int) {
+	path, cost, _ := g.ShortestPathContext(context.Background(), source, target, heuristic, SearchLimits{})
+	return path, cost
 }
 
-// AllShortestPaths returns all shortest paths from start to goal.
+// AllShortestPaths returns all shortest paths from start to goal, in lexicographic order.
 func (g Graph) AllShortestPaths(source, target string, heuristic func(n Node) int) ([][]string, int) {
 	if _, ok := g.At(source); !ok {
 		return nil, -1
@@ -204,12 +259,21 @@ func (g Graph) AllShortestPaths(source, target string, heuristic func(n Node) in
 		}
 	}
 
-	allUniquePaths := make(map[string]bool)
+	// %q quotes each name, so the key can't be confused by names containing separators
+	seen := make(map[string]bool)
+	uniquePaths := [][]string{}
 	for _, path := range allPaths {
-		allUniquePaths[strings.Join(path, "_")] = true
+		key := fmt.Sprintf("%q", path)
+		if !seen[key] {
+			seen[key] = true
+			uniquePaths = append(uniquePaths, path)
+		}
 	}
 
-	return slices.Map(func(v string) []string { return strings.Split(v, "_") }, maps.Keys(allUniquePaths)), minCost
+	// paths are found in queue order, so sort them to make the result reproducible
+	slices.SortFunc(uniquePaths, slices.Compare[[]string])
+
+	return uniquePaths, minCost
 }
 
 func (g Graph) reconstructAllPaths(cameFrom map[string][]string, current string) [][]string {
@@ -232,86 +296,27 @@ func (g Graph) reconstructAllPaths(cameFrom map[string][]string, current string)
 // AllPaths returns all paths from source to target using depth-first search.
 // Returns nil if source or target don't exist, or if no path exists.
 func (g Graph) AllPaths(source, target string) [][]string {
-	if _, ok := g.At(source); !ok {
-		return nil
-	}
-	if _, ok := g.At(target); !ok {
-		return nil
-	}
-
-	var paths [][]string
-	visited := make(map[string]bool)
-	path := make([]string, 0, 64) // Pre-allocate with reasonable capacity
-
-	var dfs func()
-	dfs = func() {
-		current := path[len(path)-1]
-		if current == target {
-			pathCopy := make([]string, len(path))
-			copy(pathCopy, path)
-			paths = append(paths, pathCopy)
-			return
-		}
-
-		visited[current] = true
-		currNode, _ := g.At(current)
-		for _, edge := range currNode.Adj {
-			if !visited[edge.Node] {
-				path = append(path, edge.Node)
-				dfs()
-				path = path[:len(path)-1]
-			}
-		}
-		visited[current] = false
-	}
-
-	path = append(path, source)
-	dfs()
+	paths, _ := g.AllPathsContext(context.Background(), source, target, SearchLimits{})
 	return paths
 }
 
 func (g Graph) CountPaths(source, target string) int {
-	if _, ok := g.At(source); !ok {
-		return -1
-	}
-	if _, ok := g.At(target); !ok {
-		return -1
-	}
-
-	dp := make(map[string]int)
-	visited := make(map[string]bool)
-
-	var dfs func(string) int
-	dfs = func(node string) int {
-		if node == target {
-			return 1
-		}
-
-		if visited[node] {
-			return 0
-		}
-
-		if count, exists := dp[node]; exists {
-			return count
-		}
-
-		visited[node] = true
-		count := 0
-
-		currNode, _ := g.At(node)
-		for _, edge := range currNode.Adj {
-			count += dfs(edge.Node)
-		}
+	count, _ := g.CountPathsContext(context.Background(), source, target, SearchLimits{})
+	return count
+}
 
-		visited[node] = false
-		dp[node] = count
-		return count
-	}
+// DFT visits every node reachable from start depth first, following edges in the order they were added
+func (g Graph) DFT(start string, visit func(n Node)) {
+	g.dft(start, visit, false)
+}
 
-	return dfs(source)
+// DFTSorted is DFT but follows the edges out of each node in order of node name,
+// so the visit order doesn't depend on how the edges were built
+func (g Graph) DFTSorted(start string, visit func(n Node)) {
+	g.dft(start, visit, true)
 }
 
-func (g Graph) DFT(start string, visit func(n Node)) {
+func (g Graph) dft(start string, visit func(n Node), sorted bool) {
 	visited := make(map[string]bool)
 	var dfs func(n *Node)
 	dfs = func(n *Node) {
@@ -320,8 +325,23 @@ func (g Graph) DFT(start string, visit func(n Node)) {
 		}
 		visited[n.Name] = true
 		visit(*n)
-		for _, edge := range n.Adj {
-			nextNode, _ := g.At(edge.Node)
+
+		if !sorted {
+			for _, edge := range n.Adj {
+				nextNode, _ := g.At(edge.Node)
+				dfs(nextNode)
+			}
+			return
+		}
+
+		next := make([]string, len(n.Adj))
+		for i, edge := range n.Adj {
+			next[i] = edge.Node
+		}
+		sort.Strings(next)
+
+		for _, name := range next {
+			nextNode, _ := g.At(name)
 			dfs(nextNode)
 		}
 	}
@@ -332,18 +352,18 @@ func (g Graph) DFT(start string, visit func(n Node)) {
 	dfs(startNode)
 }
 
+// Connected returns every node reachable from start, in the order DFT visits them
 func (g Graph) Connected(start string) []string {
-	connected := map[string]bool{}
+	connected := []string{}
 	g.DFT(start, func(n Node) {
-		connected[n.Name] = true
+		connected = append(connected, n.Name)
 	})
-	return maps.Keys(connected)
+	return connected
 }
 
 func (g Graph) Subgraphs() []string {
 	visited := map[string]bool{}
-	nodeNames := maps.Keys(g.nodeIds)
-	sort.Strings(nodeNames)
+	nodeNames := g.GetNodesSorted()
 
 	for _, k := range nodeNames {
 		visited[k] = false
@@ -368,6 +388,21 @@ func (g Graph) GetNodes() []string {
 	return maps.Keys(g.nodeIds)
 }
 
+// GetNodesSorted returns the names of all nodes in ascending order
+func (g Graph) GetNodesSorted() []string {
+	return maps.SortedKeys(g.nodeIds)
+}
+
+// knownEdges returns the edges of a node without expanding it. Nodes of a virtual graph that have
+// been expanded before have their edges generated again if the cache policy didn't keep them.
+func (g Graph) knownEdges(n *Node) []Edge {
+	if g.gen == nil || n.expanded || !n.generated {
+		return n.Adj
+	}
+	return g.gen(n)
+}
+
+// GetEdges returns the edges out of a node. The slice belongs to the graph, so it shouldn't be changed.
 func (g Graph) GetEdges(node string) []Edge {
 	n, ok := g.At(node)
 	if !ok {
@@ -1,7 +1,9 @@
 package graphs
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	stringstuff "github.com/jack-barr3tt/gostuff/strings"
@@ -21,6 +23,86 @@ func TestNewVirtualGraph(t *testing.T) {
 	test.AssertEqual(t, g.nodeIds[origin].Name, origin)
 }
 
+func TestNewVirtualGraphWithOptions(t *testing.T) {
+	type state struct {
+		pos   int
+		steps int
+	}
+
+	calls := map[string]int{}
+	gen := func(n *Node) []Edge {
+		calls[n.Name]++
+		s, _ := Payload[state](*n)
+		if s.pos >= 6 {
+			// dead end
+			return []Edge{}
+		}
+		return []Edge{
+			{Node: fmt.Sprint(s.pos + 1), Cost: 1, Data: state{pos: s.pos + 1, steps: s.steps + 1}},
+			{Node: fmt.Sprint(s.pos + 2), Cost: 1, Data: state{pos: s.pos + 2, steps: s.steps + 1}},
+		}
+	}
+
+	g := NewVirtualGraphWithOptions(gen, "0", VirtualGraphOptions{OriginData: state{}})
+
+	path, length := g.ShortestPathToAny("0", func(n Node) bool {
+		s, ok := Payload[state](n)
+		return ok && s.pos == 5
+	})
+	test.AssertEqual(t, path, []string{"0", "1", "3", "5"})
+	test.AssertEqual(t, length, 3)
+
+	node, _ := g.At("5")
+	s, ok := Payload[state](*node)
+	test.AssertEqual(t, ok, true)
+	test.AssertEqual(t, s, state{pos: 5, steps: 3})
+
+	_, ok = Payload[string](*node)
+	test.AssertEqual(t, ok, false)
+
+	// dead ends are only expanded once
+	g.At("6")
+	g.At("6")
+	test.AssertEqual(t, calls["6"], 1)
+
+	// with an LRU cache only the most recently used expansions are kept
+	calls = map[string]int{}
+	lru := NewVirtualGraphWithOptions(gen, "0", VirtualGraphOptions{Cache: CacheLRU, CacheSize: 2, OriginData: state{}})
+	lru.At("0")
+	lru.At("1")
+	lru.At("0")
+	lru.At("2")
+	lru.At("1")
+	test.AssertEqual(t, calls, map[string]int{"0": 1, "1": 2, "2": 1})
+
+	node, _ = lru.At("2")
+	s, _ = Payload[state](*node)
+	test.AssertEqual(t, s, state{pos: 2, steps: 1})
+	test.AssertEqual(t, len(node.Adj), 2)
+
+	// without a cache the generator runs on every lookup
+	calls = map[string]int{}
+	none := NewVirtualGraphWithOptions(gen, "0", VirtualGraphOptions{Cache: CacheNone, OriginData: state{}})
+	none.At("0")
+	node, _ = none.At("0")
+	test.AssertEqual(t, calls["0"], 2)
+	test.AssertEqual(t, len(node.Adj), 2)
+
+	// expanded nodes are exported with their edges whatever the cache policy
+	for _, g := range []Graph{lru, none} {
+		test.AssertEqual(t, strings.Contains(g.ToDOT(), `"0" -> "1" [label=1];`), true)
+		data, _ := json.Marshal(g)
+		test.AssertEqual(t, strings.Contains(string(data), `"0":[{"node":"1","cost":1}`), true)
+	}
+
+	// an LRU cache can't be smaller than one expansion
+	calls = map[string]int{}
+	tiny := NewVirtualGraphWithOptions(gen, "0", VirtualGraphOptions{Cache: CacheLRU, OriginData: state{}})
+	tiny.At("0")
+	tiny.At("0")
+	test.AssertEqual(t, calls["0"], 1)
+}
+
 func TestNewGraph(t *testing.T) {
 	// Test valid graph creation
 	g, err := NewGraph([]string{"a", "b", "c"}, map[string][]Edge{
@@ -195,7 +277,7 @@ func TestAllShortestPaths(t *testing.T) {
 	expected := [][]string{{"A", "B", "E"}, {"A", "C", "E"}, {"A", "D", "C", "E"}}
 
 	test.AssertEqual(t, length, 3)
-	test.AssertSlicesEqual(t, paths, expected)
+	test.AssertEqual(t, paths, expected)
 
 	// test getting all shortest paths while using a heuristic function that makes a node unreachable
 
@@ -210,6 +292,17 @@ func TestAllShortestPaths(t *testing.T) {
 
 	test.AssertEqual(t, lengthH, 3)
 	test.AssertSlicesEqual(t, pathsH, expectedH)
+
+	// test node names containing the old path separator
+	g2, _ := NewGraph([]string{"a_1", "b_2", "c_3"}, map[string][]Edge{
+		"a_1": {{Node: "b_2", Cost: 1}, {Node: "c_3", Cost: 2}},
+		"b_2": {{Node: "c_3", Cost: 1}},
+	})
+
+	paths2, length2 := g2.AllShortestPaths("a_1", "c_3", func(n Node) int { return 0 })
+
+	test.AssertEqual(t, length2, 2)
+	test.AssertSlicesEqual(t, paths2, [][]string{{"a_1", "c_3"}, {"a_1", "b_2", "c_3"}})
 }
 
 func TestDFT(t *testing.T) {
@@ -236,6 +329,26 @@ func TestDFT(t *testing.T) {
 	test.AssertSlicesEqual(t, visitedD, []string{"D", "E"})
 }
 
+func TestDFTSorted(t *testing.T) {
+	g, _ := NewGraph([]string{"A", "B", "C", "D"}, map[string][]Edge{
+		"A": {{Node: "D", Cost: 1}, {Node: "B", Cost: 1}},
+		"B": {{Node: "C", Cost: 1}},
+		"D": {{Node: "A", Cost: 1}},
+	})
+
+	visited := []string{}
+	g.DFT("A", func(n Node) {
+		visited = append(visited, n.Name)
+	})
+	test.AssertEqual(t, visited, []string{"A", "D", "B", "C"})
+
+	visitedSorted := []string{}
+	g.DFTSorted("A", func(n Node) {
+		visitedSorted = append(visitedSorted, n.Name)
+	})
+	test.AssertEqual(t, visitedSorted, []string{"A", "B", "C", "D"})
+}
+
 func TestConnected(t *testing.T) {
 	g, _ := NewGraph([]string{"A", "B", "C", "D", "E"}, map[string][]Edge{
 		"A": {{Node: "B", Cost: 1}},
@@ -250,6 +363,9 @@ func TestConnected(t *testing.T) {
 	test.AssertSlicesEqual(t, g.Connected("C"), []string{"A", "B", "C"})
 	test.AssertSlicesEqual(t, g.Connected("D"), []string{"D", "E"})
 	test.AssertSlicesEqual(t, g.Connected("E"), []string{"D", "E"})
+
+	// nodes come back in the order they were visited
+	test.AssertEqual(t, g.Connected("B"), []string{"B", "C", "A"})
 }
 
 func TestSubgraphs(t *testing.T) {
@@ -381,6 +497,13 @@ func TestGetNodes(t *testing.T) {
 	test.AssertSlicesEqual(t, nodes, expected)
 }
 
+func TestGetNodesSorted(t *testing.T) {
+	g, _ := NewGraph([]string{"C", "A", "D", "B"}, map[string][]Edge{})
+
+	test.AssertEqual(t, g.GetNodesSorted(), []string{"A", "B", "C", "D"})
+	test.AssertEqual(t, NewEmptyGraph().GetNodesSorted(), []string{})
+}
+
 func TestGetEdges(t *testing.T) {
 	g, _ := NewGraph(
 		[]string{"A", "B", "C"},
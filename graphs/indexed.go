@@ -0,0 +1,107 @@
+package graphs
+
+// Integer-indexed snapshot of part of a graph, used by algorithms that want
+// slices and bitmasks instead of string keyed maps
+
+type indexedEdge struct {
+	to   int
+	cost int
+}
+
+type indexedGraph struct {
+	names []string
+	index map[string]int
+	adj   [][]indexedEdge
+}
+
+// indexNodes builds an indexed copy of the given nodes. Edges leading to nodes
+// outside of names are dropped.
+func (g Graph) indexNodes(names []string) indexedGraph {
+	ig := indexedGraph{
+		names: names,
+		index: make(map[string]int, len(names)),
+		adj:   make([][]indexedEdge, len(names)),
+	}
+
+	for i, name := range names {
+		ig.index[name] = i
+	}
+
+	for i, name := range names {
+		for _, edge := range g.GetEdges(name) {
+			if j, ok := ig.index[edge.Node]; ok {
+				ig.adj[i] = append(ig.adj[i], indexedEdge{to: j, cost: edge.Cost})
+			}
+		}
+	}
+
+	return ig
+}
+
+// indexCache holds indexes of the whole graph, built at a version of the graph
+type indexCache struct {
+	version int
+	forward indexedGraph
+	reverse *indexedGraph
+}
+
+// cachedIndex returns the index of every node, building it again if the graph has changed.
+// The reversed index is only built if it is asked for.
+func (g Graph) cachedIndex(reverse bool) indexCache {
+	if g.state == nil {
+		ic := indexCache{forward: g.indexNodes(g.GetNodesSorted())}
+		if reverse {
+			rev := ic.forward.reversed()
+			ic.reverse = &rev
+		}
+		return ic
+	}
+
+	ic := g.state.indexed
+	version := g.state.version
+	if ic == nil || ic.version != version {
+		ic = &indexCache{version: version, forward: g.indexNodes(g.GetNodesSorted())}
+	}
+	if reverse && ic.reverse == nil {
+		rev := ic.forward.reversed()
+		ic = &indexCache{version: ic.version, forward: ic.forward, reverse: &rev}
+	}
+
+	// indexing a virtual graph can generate nodes, in which case the index is already stale
+	if g.state.version == ic.version {
+		g.state.indexed = ic
+	}
+	return *ic
+}
+
+func (ig indexedGraph) pathNames(path []int) []string {
+	out := make([]string, len(path))
+	for i, id := range path {
+		out[i] = ig.names[id]
+	}
+	return out
+}
+
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+func (b bitset) clear(i int) {
+	b[i/64] &^= 1 << (uint(i) % 64)
+}
+
+func (b bitset) clone() bitset {
+	out := make(bitset, len(b))
+	copy(out, b)
+	return out
+}
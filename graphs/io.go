@@ -0,0 +1,350 @@
+package graphs
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ToDOT renders the graph in Graphviz DOT format. Edges are labelled with their cost.
+// Any paths given are highlighted in red. Payloads are not included.
+// Only nodes of a virtual graph that have been expanded have their edges written.
+func (g Graph) ToDOT(highlight ...[]string) string {
+	highlightNodes := map[string]bool{}
+	highlightEdges := map[[2]string]bool{}
+	for _, path := range highlight {
+		for i, name := range path {
+			highlightNodes[name] = true
+			if i > 0 {
+				highlightEdges[[2]string{path[i-1], name}] = true
+			}
+		}
+	}
+
+	names := g.GetNodesSorted()
+
+	var sb strings.Builder
+	sb.WriteString("digraph {\n")
+
+	for _, name := range names {
+		if highlightNodes[name] {
+			fmt.Fprintf(&sb, "  %q [color=red];\n", name)
+		} else {
+			fmt.Fprintf(&sb, "  %q;\n", name)
+		}
+	}
+
+	for _, name := range names {
+		for _, edge := range g.knownEdges(g.nodeIds[name]) {
+			if highlightEdges[[2]string{name, edge.Node}] {
+				fmt.Fprintf(&sb, "  %q -> %q [label=%d, color=red, penwidth=2];\n", name, edge.Node, edge.Cost)
+			} else {
+				fmt.Fprintf(&sb, "  %q -> %q [label=%d];\n", name, edge.Node, edge.Cost)
+			}
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+type dotToken struct {
+	value  string
+	quoted bool
+}
+
+func tokenizeDOT(src string) ([]dotToken, error) {
+	tokens := []dotToken{}
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#' || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += 2
+		case r == '"':
+			var sb strings.Builder
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			i++
+			tokens = append(tokens, dotToken{value: sb.String(), quoted: true})
+		case r == '-' && i+1 < len(runes) && (runes[i+1] == '>' || runes[i+1] == '-'):
+			tokens = append(tokens, dotToken{value: string(runes[i : i+2])})
+			i += 2
+		case strings.ContainsRune("{}[];=,:", r):
+			tokens = append(tokens, dotToken{value: string(r)})
+			i++
+		case r == '_' || r == '.' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || runes[i] == '.' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || (i == start && runes[i] == '-')) {
+				i++
+			}
+			tokens = append(tokens, dotToken{value: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+// FromDOT parses a graph from the common subset of Graphviz DOT: node statements,
+// edge chains with -> or -- (undirected edges are added both ways) and attribute lists.
+// An edge's cost is read from its weight or label attribute, and defaults to 1.
+func FromDOT(src string) (Graph, error) {
+	tokens, err := tokenizeDOT(src)
+	if err != nil {
+		return Graph{}, err
+	}
+
+	pos := 0
+	// peek returns the next token if it is unquoted, so quoted IDs are never mistaken for operators
+	peek := func() string {
+		if pos < len(tokens) && !tokens[pos].quoted {
+			return tokens[pos].value
+		}
+		return ""
+	}
+	isEquals := func(i int) bool {
+		return i < len(tokens) && !tokens[i].quoted && tokens[i].value == "="
+	}
+	// nodeID reads the node ID at pos, which has to be quoted or an identifier or numeral
+	nodeID := func() (string, error) {
+		if pos >= len(tokens) {
+			return "", fmt.Errorf("expected a node ID")
+		}
+		tok := tokens[pos]
+		if !tok.quoted {
+			if tok.value == "{" {
+				return "", fmt.Errorf("subgraphs are not supported")
+			}
+			if r := []rune(tok.value)[0]; tok.value == "->" || tok.value == "--" || !(r == '_' || r == '.' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				return "", fmt.Errorf("expected a node ID, got %q", tok.value)
+			}
+		}
+		pos++
+		if peek() == ":" {
+			return "", fmt.Errorf("ports are not supported")
+		}
+		return tok.value, nil
+	}
+	isKeyword := func(word string) bool {
+		return pos < len(tokens) && !tokens[pos].quoted && strings.EqualFold(tokens[pos].value, word)
+	}
+
+	if isKeyword("strict") {
+		pos++
+	}
+	directed := false
+	if isKeyword("digraph") {
+		directed = true
+	} else if !isKeyword("graph") {
+		return Graph{}, fmt.Errorf("expected graph or digraph")
+	}
+	pos++
+	if peek() != "{" {
+		pos++
+	}
+	if peek() != "{" {
+		return Graph{}, fmt.Errorf("expected {")
+	}
+	pos++
+
+	g := NewEmptyGraph()
+
+	parseAttrs := func() (map[string]string, error) {
+		attrs := map[string]string{}
+		for peek() == "[" {
+			pos++
+			for peek() != "]" {
+				if pos+2 >= len(tokens) || !isEquals(pos+1) {
+					return nil, fmt.Errorf("malformed attribute list")
+				}
+				attrs[tokens[pos].value] = tokens[pos+2].value
+				pos += 3
+				if peek() == "," || peek() == ";" {
+					pos++
+				}
+			}
+			pos++
+		}
+		return attrs, nil
+	}
+
+	for peek() != "}" {
+		if pos >= len(tokens) {
+			return Graph{}, fmt.Errorf("expected }")
+		}
+		if peek() == ";" {
+			pos++
+			continue
+		}
+
+		// default attribute statements don't affect the graph structure
+		if isKeyword("graph") || isKeyword("node") || isKeyword("edge") {
+			pos++
+			if _, err := parseAttrs(); err != nil {
+				return Graph{}, err
+			}
+			continue
+		}
+		if isKeyword("subgraph") || peek() == "{" {
+			return Graph{}, fmt.Errorf("subgraphs are not supported")
+		}
+		if isEquals(pos + 1) {
+			pos += 3
+			continue
+		}
+
+		from, err := nodeID()
+		if err != nil {
+			return Graph{}, err
+		}
+		chain := []string{from}
+		for peek() == "->" || peek() == "--" {
+			pos++
+			if pos >= len(tokens) {
+				return Graph{}, fmt.Errorf("edge is missing a target")
+			}
+			to, err := nodeID()
+			if err != nil {
+				return Graph{}, err
+			}
+			chain = append(chain, to)
+		}
+
+		attrs, err := parseAttrs()
+		if err != nil {
+			return Graph{}, err
+		}
+
+		// labels are often free text, so only a weight has to be a number
+		cost := 1
+		if label, err := strconv.Atoi(attrs["label"]); err == nil {
+			cost = label
+		}
+		if weight, ok := attrs["weight"]; ok {
+			if cost, err = strconv.Atoi(weight); err != nil {
+				return Graph{}, fmt.Errorf("invalid edge weight: %s", weight)
+			}
+		}
+
+		for _, name := range chain {
+			g.AddNode(name, nil)
+		}
+		for i := 1; i < len(chain); i++ {
+			g.AddEdge(chain[i-1], chain[i], cost)
+			if !directed {
+				g.AddEdge(chain[i], chain[i-1], cost)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+var adjacencyRegex = regexp.MustCompile(`^\s*(.+?)\s*(:|->|<->)\s*(.*?)\s*$`)
+
+// ParseAdjacencyList parses the adjacency formats common in puzzle inputs, with one node per line,
+// e.g. "a: b c d" or "a -> b, c". Every edge costs 1. Blank lines are ignored.
+// A "<->" separator adds the edges in both directions.
+func ParseAdjacencyList(raw string) (Graph, error) {
+	g := NewEmptyGraph()
+
+	for i, line := range strings.Split(raw, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		matches := adjacencyRegex.FindStringSubmatch(line)
+		if matches == nil {
+			return Graph{}, fmt.Errorf("line %d has no separator: %s", i+1, line)
+		}
+
+		from := matches[1]
+		g.AddNode(from, nil)
+
+		targets := strings.FieldsFunc(matches[3], func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+		for _, to := range targets {
+			g.AddNode(to, nil)
+			g.AddEdge(from, to, 1)
+			if matches[2] == "<->" {
+				g.AddEdge(to, from, 1)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+type jsonEdge struct {
+	Node string `json:"node"`
+	Cost int    `json:"cost"`
+}
+
+type jsonGraph struct {
+	Nodes []string              `json:"nodes"`
+	Edges map[string][]jsonEdge `json:"edges"`
+}
+
+// MarshalJSON encodes the known nodes and their edges. Payloads and generators are not included.
+// Like ToDOT, only nodes of a virtual graph that have been expanded have their edges written.
+func (g Graph) MarshalJSON() ([]byte, error) {
+	jg := jsonGraph{Nodes: g.GetNodesSorted(), Edges: map[string][]jsonEdge{}}
+
+	for _, name := range jg.Nodes {
+		for _, edge := range g.knownEdges(g.nodeIds[name]) {
+			jg.Edges[name] = append(jg.Edges[name], jsonEdge{Node: edge.Node, Cost: edge.Cost})
+		}
+	}
+
+	return json.Marshal(jg)
+}
+
+// UnmarshalJSON decodes a graph written by MarshalJSON, validating it like NewGraph does
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	var jg jsonGraph
+	if err := json.Unmarshal(data, &jg); err != nil {
+		return err
+	}
+
+	edges := map[string][]Edge{}
+	for name, jsonEdges := range jg.Edges {
+		for _, e := range jsonEdges {
+			edges[name] = append(edges[name], Edge{Node: e.Node, Cost: e.Cost})
+		}
+	}
+
+	parsed, err := NewGraph(jg.Nodes, edges)
+	if err != nil {
+		return err
+	}
+
+	*g = parsed
+	return nil
+}
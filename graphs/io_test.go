@@ -0,0 +1,137 @@
+package graphs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestToDOT(t *testing.T) {
+	g, _ := NewGraph([]string{"A", "B", "C"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}, {Node: "C", Cost: 4}},
+		"B": {{Node: "C", Cost: 2}},
+	})
+
+	test.AssertEqual(t, g.ToDOT(), `digraph {
+  "A";
+  "B";
+  "C";
+  "A" -> "B" [label=1];
+  "A" -> "C" [label=4];
+  "B" -> "C" [label=2];
+}
+`)
+
+	highlighted := g.ToDOT([]string{"A", "B", "C"})
+	test.AssertEqual(t, strings.Contains(highlighted, `"A" [color=red];`), true)
+	test.AssertEqual(t, strings.Contains(highlighted, `"A" -> "B" [label=1, color=red, penwidth=2];`), true)
+	test.AssertEqual(t, strings.Contains(highlighted, `"A" -> "C" [label=4];`), true)
+}
+
+func TestFromDOT(t *testing.T) {
+	// round trip
+	g := textbookGraph()
+	parsed, err := FromDOT(g.ToDOT([]string{"S", "C", "B"}))
+	test.AssertEqual(t, err, nil)
+	test.AssertSlicesEqual(t, parsed.GetNodes(), g.GetNodes())
+	for _, name := range g.GetNodes() {
+		test.AssertSlicesEqual(t, parsed.GetEdges(name), g.GetEdges(name))
+	}
+
+	// undirected graphs, chains, comments and default attributes
+	parsed, err = FromDOT(`strict graph network {
+		// a comment
+		node [shape=box];
+		rankdir = LR;
+		a -- b -- c [weight=3];
+		/* another
+		   comment */
+		c -- "d e" [label="not a number"]
+		f
+	}`)
+	test.AssertEqual(t, err, nil)
+	test.AssertSlicesEqual(t, parsed.GetNodes(), []string{"a", "b", "c", "d e", "f"})
+	test.AssertSlicesEqual(t, parsed.GetEdges("b"), []Edge{{Node: "a", Cost: 3}, {Node: "c", Cost: 3}})
+	test.AssertSlicesEqual(t, parsed.GetEdges("d e"), []Edge{{Node: "c", Cost: 1}})
+	test.AssertSlicesEqual(t, parsed.GetEdges("f"), []Edge{})
+
+	// quoted IDs that look like operators are still IDs
+	g, _ = NewGraph([]string{"a->b", "--", "}", "="}, map[string][]Edge{
+		"a->b": {{Node: "--", Cost: 2}},
+		"--":   {{Node: "}", Cost: 3}, {Node: "=", Cost: 4}},
+	})
+	parsed, err = FromDOT(g.ToDOT())
+	test.AssertEqual(t, err, nil)
+	test.AssertSlicesEqual(t, parsed.GetNodes(), g.GetNodes())
+	for _, name := range g.GetNodes() {
+		test.AssertSlicesEqual(t, parsed.GetEdges(name), g.GetEdges(name))
+	}
+
+	_, err = FromDOT(`digraph { a -> b [weight=x] }`)
+	test.AssertEqual(t, err != nil, true)
+
+	_, err = FromDOT(`digraph { a -> b`)
+	test.AssertEqual(t, err != nil, true)
+
+	_, err = FromDOT(`not a graph`)
+	test.AssertEqual(t, err != nil, true)
+
+	// syntax that isn't supported is an error rather than a wrong graph
+	_, err = FromDOT(`digraph { a -> {b c} }`)
+	test.AssertEqual(t, err.Error(), "subgraphs are not supported")
+
+	_, err = FromDOT(`digraph { a:p -> b }`)
+	test.AssertEqual(t, err.Error(), "ports are not supported")
+
+	_, err = FromDOT(`digraph { a -> b:p }`)
+	test.AssertEqual(t, err.Error(), "ports are not supported")
+
+	_, err = FromDOT(`digraph { a -> ; }`)
+	test.AssertEqual(t, err != nil, true)
+
+	_, err = FromDOT(`digraph { , a }`)
+	test.AssertEqual(t, err != nil, true)
+}
+
+func TestParseAdjacencyList(t *testing.T) {
+	g, err := ParseAdjacencyList(`aaa: you hhh
+you: bbb ccc
+
+bbb -> ddd, eee
+ccc <-> fff
+`)
+	test.AssertEqual(t, err, nil)
+	test.AssertSlicesEqual(t, g.GetNodes(), []string{"aaa", "you", "hhh", "bbb", "ccc", "ddd", "eee", "fff"})
+	test.AssertSlicesEqual(t, g.GetEdges("aaa"), []Edge{{Node: "you", Cost: 1}, {Node: "hhh", Cost: 1}})
+	test.AssertSlicesEqual(t, g.GetEdges("bbb"), []Edge{{Node: "ddd", Cost: 1}, {Node: "eee", Cost: 1}})
+	test.AssertSlicesEqual(t, g.GetEdges("fff"), []Edge{{Node: "ccc", Cost: 1}})
+	test.AssertEqual(t, g.CountPaths("aaa", "eee"), 1)
+
+	_, err = ParseAdjacencyList("a b c")
+	test.AssertEqual(t, err != nil, true)
+}
+
+func TestGraphJSON(t *testing.T) {
+	g, _ := NewGraph([]string{"A", "B", "C"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}, {Node: "C", Cost: 4}},
+		"B": {{Node: "C", Cost: 2}},
+	})
+
+	data, err := json.Marshal(g)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, string(data), `{"nodes":["A","B","C"],"edges":{"A":[{"node":"B","cost":1},{"node":"C","cost":4}],"B":[{"node":"C","cost":2}]}}`)
+
+	var decoded Graph
+	err = json.Unmarshal(data, &decoded)
+	test.AssertEqual(t, err, nil)
+	test.AssertSlicesEqual(t, decoded.GetNodes(), []string{"A", "B", "C"})
+	test.AssertSlicesEqual(t, decoded.GetEdges("A"), g.GetEdges("A"))
+
+	_, length := decoded.ShortestPath("A", "C", func(n Node) int { return 0 })
+	test.AssertEqual(t, length, 3)
+
+	err = json.Unmarshal([]byte(`{"nodes":["A"],"edges":{"A":[{"node":"B","cost":1}]}}`), &decoded)
+	test.AssertEqual(t, err != nil, true)
+}
@@ -0,0 +1,216 @@
+package graphs
+
+import (
+	"context"
+	"errors"
+)
+
+// SearchLimits bounds how much work a search may do, and lets the caller watch it as it runs.
+// A zero limit means no limit.
+type SearchLimits struct {
+	// MaxExpanded is the most nodes the search may expand before giving up
+	MaxExpanded int
+	// MaxDepth is the most edges a path may have
+	MaxDepth int
+	// MaxCost is the highest total cost a path may have
+	MaxCost int
+	// Hooks are called as the search runs
+	Hooks SearchHooks
+	// Stats, if not nil, is filled in with the work the search did, even if it stopped early
+	Stats *SearchStats
+}
+
+var (
+	ErrExpansionLimit = errors.New("search expanded the maximum number of nodes")
+	ErrDepthLimit     = errors.New("search reached the maximum path depth")
+	ErrCostLimit      = errors.New("search reached the maximum path cost")
+)
+
+// tracker follows a search, enforcing its context and limits, counting stats and calling hooks
+type tracker struct {
+	ctx    context.Context
+	limits SearchLimits
+	hooks  SearchHooks
+	stats  SearchStats
+	// pruned is set once a path has been cut short by the depth or cost limit
+	pruned error
+}
+
+func newTracker(ctx context.Context, limits SearchLimits) *tracker {
+	return &tracker{ctx: ctx, limits: limits, hooks: limits.Hooks}
+}
+
+// report hands the stats to the caller, if they asked for them
+func (tr *tracker) report() {
+	if tr.limits.Stats != nil {
+		*tr.limits.Stats = tr.stats
+	}
+}
+
+// expand is called before each node is expanded and returns an error if the search has to stop
+func (tr *tracker) expand(n Node, cost int) error {
+	if err := tr.ctx.Err(); err != nil {
+		return err
+	}
+	if tr.limits.MaxExpanded > 0 && tr.stats.Expanded >= tr.limits.MaxExpanded {
+		return ErrExpansionLimit
+	}
+	tr.stats.Expanded++
+	if tr.hooks.OnExpand != nil {
+		tr.hooks.OnExpand(n, cost)
+	}
+	return nil
+}
+
+// allow returns whether a path with this many edges and this cost may be followed
+func (tr *tracker) allow(depth, cost int) bool {
+	if tr.limits.MaxDepth > 0 && depth > tr.limits.MaxDepth {
+		tr.pruned = ErrDepthLimit
+		return false
+	}
+	if tr.limits.MaxCost > 0 && cost > tr.limits.MaxCost {
+		tr.pruned = ErrCostLimit
+		return false
+	}
+	return true
+}
+
+// ShortestPathContext is ShortestPath with cancellation and limits. If the search stops early, or
+// finds nothing after cutting paths short, the error says why. It is ErrExpansionLimit, ErrDepthLimit,
+// ErrCostLimit or the context's error. Paths cut short by MaxDepth may hide a cheaper path that
+// is within the limit, and with negative edges so may paths cut short by MaxCost.
+// If negative edges make a negative cycle reachable, a *NegativeCycleError is returned.
+// Returns nil, -1 if no path was found.
+func (g Graph) ShortestPathContext(ctx context.Context, source, target string, heuristic func(n Node) int, limits SearchLimits) ([]string, int, error) {
+	if _, ok := g.At(source); !ok {
+		return nil, -1, nil
+	}
+
+	tr := newTracker(ctx, limits)
+	defer tr.report()
+	if g.gen == nil && g.HasNegativeEdges() {
+		reachable := g.indexReachable(source, func(n Node) bool { return heuristic(n) != -1 })
+		return reachable.spfa(source, target, tr, func(name string) Node { return *g.nodeIds[name] })
+	}
+
+	return g.search(tr, []string{source}, func(n Node) bool { return n.Name == target }, heuristic)
+}
+
+// AllPathsContext is AllPaths with cancellation and limits. If the search stops early, or cut
+// any path short, the paths found so far are returned along with an error saying why.
+func (g Graph) AllPathsContext(ctx context.Context, source, target string, limits SearchLimits) ([][]string, error) {
+	if _, ok := g.At(source); !ok {
+		return nil, nil
+	}
+	if _, ok := g.At(target); !ok {
+		return nil, nil
+	}
+
+	tr := newTracker(ctx, limits)
+	defer tr.report()
+	var paths [][]string
+	var stopped error
+	visited := make(map[string]bool)
+	path := make([]string, 0, 64) // Pre-allocate with reasonable capacity
+
+	var dfs func(cost int)
+	dfs = func(cost int) {
+		current := path[len(path)-1]
+		if current == target {
+			pathCopy := make([]string, len(path))
+			copy(pathCopy, path)
+			paths = append(paths, pathCopy)
+			return
+		}
+
+		currNode, _ := g.At(current)
+		if stopped = tr.expand(*currNode, cost); stopped != nil {
+			return
+		}
+
+		visited[current] = true
+		for _, edge := range currNode.Adj {
+			if !visited[edge.Node] && tr.allow(len(path), cost+edge.Cost) {
+				path = append(path, edge.Node)
+				dfs(cost + edge.Cost)
+				path = path[:len(path)-1]
+				if stopped != nil {
+					break
+				}
+			}
+		}
+		visited[current] = false
+	}
+
+	path = append(path, source)
+	dfs(0)
+
+	if stopped != nil {
+		return paths, stopped
+	}
+	return paths, tr.pruned
+}
+
+// CountPathsContext is CountPaths with cancellation and limits. If the search stops early, or cut
+// any path short, the count so far is returned along with an error saying why.
+// Counts can't be reused between paths of different lengths, so MaxDepth and MaxCost make this much slower.
+func (g Graph) CountPathsContext(ctx context.Context, source, target string, limits SearchLimits) (int, error) {
+	if _, ok := g.At(source); !ok {
+		return -1, nil
+	}
+	if _, ok := g.At(target); !ok {
+		return -1, nil
+	}
+
+	tr := newTracker(ctx, limits)
+	defer tr.report()
+	memo := limits.MaxDepth == 0 && limits.MaxCost == 0
+	var stopped error
+	dp := make(map[string]int)
+	visited := make(map[string]bool)
+
+	var dfs func(node string, depth, cost int) int
+	dfs = func(node string, depth, cost int) int {
+		if node == target {
+			return 1
+		}
+
+		if visited[node] {
+			return 0
+		}
+
+		if count, exists := dp[node]; exists {
+			return count
+		}
+
+		currNode, _ := g.At(node)
+		if stopped = tr.expand(*currNode, cost); stopped != nil {
+			return 0
+		}
+
+		visited[node] = true
+		count := 0
+
+		for _, edge := range currNode.Adj {
+			if tr.allow(depth+1, cost+edge.Cost) {
+				count += dfs(edge.Node, depth+1, cost+edge.Cost)
+			}
+			if stopped != nil {
+				break
+			}
+		}
+
+		visited[node] = false
+		if memo && stopped == nil {
+			dp[node] = count
+		}
+		return count
+	}
+
+	count := dfs(source, 0, 0)
+
+	if stopped != nil {
+		return count, stopped
+	}
+	return count, tr.pruned
+}
@@ -0,0 +1,138 @@
+package graphs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	stringstuff "github.com/jack-barr3tt/gostuff/strings"
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+// an endless number line where every step goes up by 2 or 3, so odd targets below 3 are never reached
+func numberLine() Graph {
+	return NewVirtualGraph(func(n *Node) []Edge {
+		x := stringstuff.GetNum(n.Name)
+		return []Edge{
+			{Node: fmt.Sprint(x + 2), Cost: 2},
+			{Node: fmt.Sprint(x + 3), Cost: 3},
+		}
+	}, "0")
+}
+
+func TestShortestPathContext(t *testing.T) {
+	g := numberLine()
+	zero := func(n Node) int { return 0 }
+
+	path, cost, err := g.ShortestPathContext(context.Background(), "0", "9", zero, SearchLimits{MaxExpanded: 1000})
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, cost, 9)
+	test.AssertEqual(t, path[len(path)-1], "9")
+
+	_, cost, err = g.ShortestPathContext(context.Background(), "0", "1", zero, SearchLimits{MaxExpanded: 100})
+	test.AssertEqual(t, errors.Is(err, ErrExpansionLimit), true)
+	test.AssertEqual(t, cost, -1)
+
+	_, _, err = g.ShortestPathContext(context.Background(), "0", "1", zero, SearchLimits{MaxCost: 50})
+	test.AssertEqual(t, errors.Is(err, ErrCostLimit), true)
+
+	_, _, err = g.ShortestPathContext(context.Background(), "0", "1", zero, SearchLimits{MaxDepth: 10})
+	test.AssertEqual(t, errors.Is(err, ErrDepthLimit), true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = g.ShortestPathContext(ctx, "0", "1", zero, SearchLimits{})
+	test.AssertEqual(t, errors.Is(err, context.Canceled), true)
+
+	// finite graphs finish without an error
+	_, cost, err = textbookGraph().ShortestPathContext(context.Background(), "S", "T", zero, SearchLimits{})
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, cost, 11)
+
+	// negative edges are searched with SPFA, which follows the same limits
+	path, cost, err = negativeGraph().ShortestPathContext(context.Background(), "A", "D", zero, SearchLimits{})
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, path, []string{"A", "C", "B", "D"})
+	test.AssertEqual(t, cost, 0)
+
+	_, _, err = negativeGraph().ShortestPathContext(ctx, "A", "D", zero, SearchLimits{})
+	test.AssertEqual(t, errors.Is(err, context.Canceled), true)
+
+	_, _, err = negativeGraph().ShortestPathContext(context.Background(), "A", "D", zero, SearchLimits{MaxExpanded: 1})
+	test.AssertEqual(t, errors.Is(err, ErrExpansionLimit), true)
+
+	_, cost, err = negativeGraph().ShortestPathContext(context.Background(), "A", "D", zero, SearchLimits{MaxDepth: 1})
+	test.AssertEqual(t, errors.Is(err, ErrDepthLimit), true)
+	test.AssertEqual(t, cost, -1)
+
+	// a negative cycle is reported rather than looking like there is no path
+	cyclic := negativeGraph()
+	cyclic.AddEdge("B", "C", -2)
+	_, cost, err = cyclic.ShortestPathContext(context.Background(), "A", "D", zero, SearchLimits{})
+	var cycleErr *NegativeCycleError
+	test.AssertEqual(t, errors.As(err, &cycleErr), true)
+	test.AssertEqual(t, cost, -1)
+
+	// virtual graphs are searched with A*, which also stops at a negative cycle
+	loop := NewVirtualGraph(func(n *Node) []Edge {
+		x := stringstuff.GetNum(n.Name)
+		edges := []Edge{{Node: fmt.Sprint(x + 1), Cost: 1}}
+		if x == 3 {
+			edges = append(edges, Edge{Node: "1", Cost: -5})
+		}
+		return edges
+	}, "0")
+	_, _, err = loop.ShortestPathContext(context.Background(), "0", "-1", zero, SearchLimits{})
+	test.AssertEqual(t, errors.As(err, &cycleErr), true)
+	test.AssertEqual(t, cycleErr.Cycle, []string{"3", "1", "2"})
+
+	path, cost = loop.ShortestPath("0", "-1", zero)
+	test.AssertEqual(t, path == nil, true)
+	test.AssertEqual(t, cost, -1)
+}
+
+func TestAllPathsContext(t *testing.T) {
+	g := numberLine()
+	// the target has to have been generated already
+	g.ShortestPath("0", "7", func(n Node) int { return 0 })
+
+	paths, err := g.AllPathsContext(context.Background(), "0", "7", SearchLimits{MaxDepth: 3})
+	test.AssertEqual(t, errors.Is(err, ErrDepthLimit), true)
+	test.AssertSlicesEqual(t, paths, [][]string{{"0", "2", "4", "7"}, {"0", "2", "5", "7"}, {"0", "3", "5", "7"}})
+
+	paths, err = g.AllPathsContext(context.Background(), "0", "7", SearchLimits{MaxDepth: 3, MaxExpanded: 4})
+	test.AssertEqual(t, errors.Is(err, ErrExpansionLimit), true)
+	test.AssertEqual(t, len(paths), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	paths, err = g.AllPathsContext(ctx, "0", "7", SearchLimits{})
+	test.AssertEqual(t, errors.Is(err, context.Canceled), true)
+	test.AssertEqual(t, len(paths), 0)
+
+	// a limit that is never reached doesn't cause an error
+	paths, err = textbookGraph().AllPathsContext(context.Background(), "S", "T", SearchLimits{MaxDepth: 100})
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, len(paths), len(textbookGraph().AllPaths("S", "T")))
+}
+
+func TestCountPathsContext(t *testing.T) {
+	g := numberLine()
+	g.ShortestPath("0", "12", func(n Node) int { return 0 })
+
+	count, err := g.CountPathsContext(context.Background(), "0", "7", SearchLimits{MaxDepth: 3})
+	test.AssertEqual(t, errors.Is(err, ErrDepthLimit), true)
+	test.AssertEqual(t, count, 3)
+
+	count, err = g.CountPathsContext(context.Background(), "0", "12", SearchLimits{MaxCost: 12})
+	test.AssertEqual(t, errors.Is(err, ErrCostLimit), true)
+	test.AssertEqual(t, count, 12)
+
+	_, err = g.CountPathsContext(context.Background(), "0", "7", SearchLimits{MaxExpanded: 50})
+	test.AssertEqual(t, errors.Is(err, ErrExpansionLimit), true)
+
+	count, err = textbookGraph().CountPathsContext(context.Background(), "S", "T", SearchLimits{})
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, count, textbookGraph().CountPaths("S", "T"))
+}
@@ -0,0 +1,252 @@
+package graphs
+
+import (
+	"math"
+	"sort"
+	"sync/atomic"
+
+	"github.com/jack-barr3tt/gostuff/slices"
+)
+
+type longestSearch struct {
+	ig     indexedGraph
+	source int
+	target int
+	maxIn  []int
+	best   atomic.Int64
+}
+
+type longestBranch struct {
+	path      []int
+	cost      int
+	remaining int
+	visited   bitset
+}
+
+type longestResult struct {
+	path  []int
+	cost  int
+	found bool
+}
+
+// LongestPath returns the longest simple path from source to target and its cost.
+// Visited nodes are tracked in a bitmask over integer node ids, and branches whose
+// optimistic upper bound cannot beat the best path found so far are pruned.
+// Returns nil, -1 if source or target don't exist, or if no path exists.
+func (g Graph) LongestPath(source, target string) ([]string, int) {
+	return g.LongestPathParallel(source, target, 1)
+}
+
+// LongestPathParallel is LongestPath with the search split into branches that are
+// explored by coreCount workers. Workers share the best cost found so far for pruning.
+func (g Graph) LongestPathParallel(source, target string, coreCount int) ([]string, int) {
+	if _, ok := g.At(source); !ok {
+		return nil, -1
+	}
+	if _, ok := g.At(target); !ok {
+		return nil, -1
+	}
+	if coreCount < 1 {
+		coreCount = 1
+	}
+
+	ls, ok := g.newLongestSearch(source, target)
+	if !ok {
+		return nil, -1
+	}
+
+	branches := []longestBranch{ls.root()}
+	if coreCount > 1 {
+		branches = ls.split(branches, coreCount*4)
+	}
+	if len(branches) == 0 {
+		return nil, -1
+	}
+	if coreCount > len(branches) {
+		coreCount = len(branches)
+	}
+
+	results := slices.ParallelMap(ls.run, branches, coreCount)
+
+	best := longestResult{}
+	for _, r := range results {
+		if r.found && (!best.found || r.cost > best.cost) {
+			best = r
+		}
+	}
+	if !best.found {
+		return nil, -1
+	}
+
+	return ls.ig.pathNames(best.path), best.cost
+}
+
+// newLongestSearch indexes the nodes that are reachable from source and can also
+// reach target, as no other node can be part of a path between them.
+func (g Graph) newLongestSearch(source, target string) (*longestSearch, bool) {
+	reachable := g.Connected(source)
+	sort.Strings(reachable)
+	full := g.indexNodes(reachable)
+
+	targetId, ok := full.index[target]
+	if !ok {
+		return nil, false
+	}
+
+	reverse := make([][]int, len(full.names))
+	for from, edges := range full.adj {
+		for _, e := range edges {
+			reverse[e.to] = append(reverse[e.to], from)
+		}
+	}
+
+	useful := newBitset(len(full.names))
+	useful.set(targetId)
+	stack := []int{targetId}
+	for len(stack) > 0 {
+		curr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, prev := range reverse[curr] {
+			if !useful.has(prev) {
+				useful.set(prev)
+				stack = append(stack, prev)
+			}
+		}
+	}
+
+	names := []string{}
+	for i, name := range full.names {
+		if useful.has(i) {
+			names = append(names, name)
+		}
+	}
+
+	ig := g.indexNodes(names)
+	if _, ok := ig.index[source]; !ok {
+		return nil, false
+	}
+
+	ls := &longestSearch{
+		ig:     ig,
+		source: ig.index[source],
+		target: ig.index[target],
+		maxIn:  make([]int, len(names)),
+	}
+	ls.best.Store(math.MinInt64)
+
+	// The best a node can ever add to a path is its most expensive incoming edge
+	for _, edges := range ig.adj {
+		for _, e := range edges {
+			if e.cost > ls.maxIn[e.to] {
+				ls.maxIn[e.to] = e.cost
+			}
+		}
+	}
+
+	return ls, true
+}
+
+func (ls *longestSearch) root() longestBranch {
+	visited := newBitset(len(ls.ig.names))
+	visited.set(ls.source)
+
+	remaining := 0
+	for i, m := range ls.maxIn {
+		if i != ls.source {
+			remaining += m
+		}
+	}
+
+	return longestBranch{path: []int{ls.source}, remaining: remaining, visited: visited}
+}
+
+// split expands branches breadth first until there are at least count of them
+// or none of them can be extended any further
+func (ls *longestSearch) split(branches []longestBranch, count int) []longestBranch {
+	for len(branches) < count {
+		next := []longestBranch{}
+		grew := false
+
+		for _, b := range branches {
+			last := b.path[len(b.path)-1]
+			if last == ls.target {
+				next = append(next, b)
+				continue
+			}
+
+			for _, e := range ls.ig.adj[last] {
+				if b.visited.has(e.to) {
+					continue
+				}
+
+				visited := b.visited.clone()
+				visited.set(e.to)
+				path := make([]int, len(b.path), len(b.path)+1)
+				copy(path, b.path)
+
+				next = append(next, longestBranch{
+					path:      append(path, e.to),
+					cost:      b.cost + e.cost,
+					remaining: b.remaining - ls.maxIn[e.to],
+					visited:   visited,
+				})
+				grew = true
+			}
+		}
+
+		branches = next
+		if !grew {
+			break
+		}
+	}
+
+	return branches
+}
+
+func (ls *longestSearch) offer(cost int) {
+	for {
+		curr := ls.best.Load()
+		if int64(cost) <= curr || ls.best.CompareAndSwap(curr, int64(cost)) {
+			return
+		}
+	}
+}
+
+func (ls *longestSearch) run(b longestBranch) longestResult {
+	result := longestResult{}
+	visited := b.visited.clone()
+	path := make([]int, len(b.path), len(ls.ig.names))
+	copy(path, b.path)
+
+	var dfs func(node, cost, remaining int)
+	dfs = func(node, cost, remaining int) {
+		if node == ls.target {
+			if !result.found || cost > result.cost {
+				result.path = make([]int, len(path))
+				copy(result.path, path)
+				result.cost = cost
+				result.found = true
+				ls.offer(cost)
+			}
+			return
+		}
+
+		if int64(cost+remaining) <= ls.best.Load() {
+			return
+		}
+
+		for _, e := range ls.ig.adj[node] {
+			if visited.has(e.to) {
+				continue
+			}
+			visited.set(e.to)
+			path = append(path, e.to)
+			dfs(e.to, cost+e.cost, remaining-ls.maxIn[e.to])
+			path = path[:len(path)-1]
+			visited.clear(e.to)
+		}
+	}
+
+	dfs(path[len(path)-1], b.cost, b.remaining)
+	return result
+}
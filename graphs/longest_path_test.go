@@ -0,0 +1,87 @@
+package graphs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestLongestPath(t *testing.T) {
+	g, _ := NewGraph([]string{"S", "A", "B", "C", "D", "T"}, map[string][]Edge{
+		"S": {{Node: "A", Cost: 5}, {Node: "B", Cost: 6}, {Node: "C", Cost: 2}},
+		"A": {{Node: "S", Cost: 5}, {Node: "D", Cost: 4}},
+		"B": {{Node: "S", Cost: 6}, {Node: "D", Cost: 4}, {Node: "T", Cost: 8}, {Node: "C", Cost: 2}},
+		"C": {{Node: "S", Cost: 2}, {Node: "B", Cost: 2}, {Node: "T", Cost: 12}},
+		"D": {{Node: "A", Cost: 4}, {Node: "B", Cost: 4}, {Node: "T", Cost: 3}},
+		"T": {{Node: "B", Cost: 8}, {Node: "C", Cost: 12}, {Node: "D", Cost: 3}},
+	})
+
+	path, length := g.LongestPath("S", "T")
+
+	test.AssertEqual(t, path, []string{"S", "A", "D", "B", "C", "T"})
+	test.AssertEqual(t, length, 27)
+
+	for workers := 2; workers <= 16; workers++ {
+		_, parallelLength := g.LongestPathParallel("S", "T", workers)
+		test.AssertEqual(t, parallelLength, 27)
+	}
+
+	// Test unreachable and non-existent nodes
+	g2, _ := NewGraph([]string{"A", "B", "C"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}},
+	})
+
+	path2, length2 := g2.LongestPath("A", "C")
+	test.AssertEqual(t, path2 == nil, true)
+	test.AssertEqual(t, length2, -1)
+
+	_, length3 := g2.LongestPath("A", "nonexistent")
+	test.AssertEqual(t, length3, -1)
+}
+
+func TestLongestPathMatchesAllPaths(t *testing.T) {
+	// 4x4 grid where every cell connects to its neighbours, similar to a maze junction graph
+	size := 4
+	nodes := []string{}
+	edges := map[string][]Edge{}
+	name := func(x, y int) string { return fmt.Sprintf("%d,%d", x, y) }
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			nodes = append(nodes, name(x, y))
+			for _, d := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+				nx, ny := x+d[0], y+d[1]
+				if nx >= 0 && nx < size && ny >= 0 && ny < size {
+					edges[name(x, y)] = append(edges[name(x, y)], Edge{Node: name(nx, ny), Cost: (x*7+y*3+nx+ny)%5 + 1})
+				}
+			}
+		}
+	}
+
+	g, _ := NewGraph(nodes, edges)
+
+	expected := 0
+	for _, p := range g.AllPaths("0,0", "3,3") {
+		cost := 0
+		for i := 0; i < len(p)-1; i++ {
+			for _, e := range g.GetEdges(p[i]) {
+				if e.Node == p[i+1] {
+					cost += e.Cost
+					break
+				}
+			}
+		}
+		if cost > expected {
+			expected = cost
+		}
+	}
+
+	_, length := g.LongestPath("0,0", "3,3")
+	test.AssertEqual(t, length, expected)
+
+	for workers := 2; workers <= 16; workers++ {
+		_, parallelLength := g.LongestPathParallel("0,0", "3,3", workers)
+		test.AssertEqual(t, parallelLength, expected)
+	}
+}
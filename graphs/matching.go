@@ -0,0 +1,279 @@
+package graphs
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jack-barr3tt/gostuff/nums"
+	"github.com/jack-barr3tt/gostuff/set"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+// hopcroftKarp finds a maximum matching where adj[u] lists the right hand nodes left node u can take.
+// Returns the right node matched to each left node, or -1 if it is unmatched.
+func hopcroftKarp(adj [][]int, nRight int) []int {
+	matchL := make([]int, len(adj))
+	matchR := make([]int, nRight)
+	for i := range matchL {
+		matchL[i] = -1
+	}
+	for i := range matchR {
+		matchR[i] = -1
+	}
+	dist := make([]int, len(adj))
+
+	// layer the free left nodes and everything reachable from them by alternating paths
+	bfs := func() bool {
+		queue := []int{}
+		for u := range adj {
+			if matchL[u] == -1 {
+				dist[u] = 0
+				queue = append(queue, u)
+			} else {
+				dist[u] = -1
+			}
+		}
+
+		found := false
+		for i := 0; i < len(queue); i++ {
+			u := queue[i]
+			for _, v := range adj[u] {
+				if w := matchR[v]; w == -1 {
+					found = true
+				} else if dist[w] == -1 {
+					dist[w] = dist[u] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		for _, v := range adj[u] {
+			if w := matchR[v]; w == -1 || (dist[w] == dist[u]+1 && dfs(w)) {
+				matchL[u] = v
+				matchR[v] = u
+				return true
+			}
+		}
+		dist[u] = -1
+		return false
+	}
+
+	for bfs() {
+		for u := range adj {
+			if matchL[u] == -1 {
+				dfs(u)
+			}
+		}
+	}
+
+	return matchL
+}
+
+// HopcroftKarp returns a maximum matching between rows and columns, where allowed[i][j]
+// says whether row i may be paired with column j. Pairs are (row, column) in row order.
+func HopcroftKarp(allowed [][]bool) []types.Pair[int, int] {
+	adj := make([][]int, len(allowed))
+	nRight := 0
+	for i, row := range allowed {
+		nRight = nums.Max(nRight, len(row))
+		for j, ok := range row {
+			if ok {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+
+	pairs := []types.Pair[int, int]{}
+	for i, j := range hopcroftKarp(adj, nRight) {
+		if j != -1 {
+			pairs = append(pairs, types.Pair[int, int]{First: i, Second: j})
+		}
+	}
+	return pairs
+}
+
+// rightSide returns the sorted targets of edges leaving the left nodes that aren't left nodes themselves
+func (g Graph) rightSide(left []string) []string {
+	isLeft := set.FromSlice(left)
+	right := set.New[string]()
+	for _, name := range left {
+		for _, edge := range g.GetEdges(name) {
+			if !isLeft.Has(edge.Node) {
+				right.Add(edge.Node)
+			}
+		}
+	}
+	return set.Sorted(right)
+}
+
+// MaxBipartiteMatching pairs as many of the left nodes as possible with a distinct node
+// they have an edge to, using the Hopcroft-Karp algorithm. Edges between left nodes are ignored.
+// Pairs are (left, right) in the order left is given.
+func (g Graph) MaxBipartiteMatching(left []string) []types.Pair[string, string] {
+	right := g.rightSide(left)
+	rightIndex := make(map[string]int)
+	for i, name := range right {
+		rightIndex[name] = i
+	}
+
+	adj := make([][]int, len(left))
+	for i, name := range left {
+		for _, edge := range g.GetEdges(name) {
+			if j, ok := rightIndex[edge.Node]; ok {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+
+	pairs := []types.Pair[string, string]{}
+	for i, j := range hopcroftKarp(adj, len(right)) {
+		if j != -1 {
+			pairs = append(pairs, types.Pair[string, string]{First: left[i], Second: right[j]})
+		}
+	}
+	return pairs
+}
+
+// Hungarian solves the assignment problem for a cost matrix, pairing every row with a distinct
+// column (or every column with a distinct row, if there are more rows) for the lowest total cost.
+// Every row must have the same length. Pairs are (row, column) in row order.
+func Hungarian(cost [][]int) ([]types.Pair[int, int], int) {
+	if len(cost) == 0 || len(cost[0]) == 0 {
+		return []types.Pair[int, int]{}, 0
+	}
+
+	// the algorithm needs at least as many columns as rows
+	a, transposed := cost, false
+	if len(cost) > len(cost[0]) {
+		a, transposed = make([][]int, len(cost[0])), true
+		for j := range a {
+			a[j] = make([]int, len(cost))
+			for i := range cost {
+				a[j][i] = cost[i][j]
+			}
+		}
+	}
+	n, m := len(a), len(a[0])
+
+	// potentials u and v keep every reduced cost a[i][j]-u[i]-v[j] non-negative, and p[j] is the
+	// row assigned to column j. Both are 1-indexed with column 0 as a virtual starting column.
+	u := make([]int, n+1)
+	v := make([]int, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]int, m+1)
+		for j := range minv {
+			minv[j] = Infinity
+		}
+		used := make([]bool, m+1)
+
+		// grow a tree of tight edges until it reaches a free column
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], Infinity, 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := a[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+
+		// flip the augmenting path back to the start
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	pairs := []types.Pair[int, int]{}
+	total := 0
+	for j := 1; j <= m; j++ {
+		if p[j] == 0 {
+			continue
+		}
+		pair := types.Pair[int, int]{First: p[j] - 1, Second: j - 1}
+		if transposed {
+			pair = types.Pair[int, int]{First: j - 1, Second: p[j] - 1}
+		}
+		pairs = append(pairs, pair)
+		total += cost[pair.First][pair.Second]
+	}
+	slices.SortFunc(pairs, func(x, y types.Pair[int, int]) int { return x.First - y.First })
+
+	return pairs, total
+}
+
+// MinCostAssignment assigns every left node to a distinct node it has an edge to, minimising
+// the total cost of the edges used. If a node has several edges to the same target the cheapest counts.
+// Returns an error if there is no way to assign all of them.
+func (g Graph) MinCostAssignment(left []string) ([]types.Pair[string, string], int, error) {
+	right := g.rightSide(left)
+	if len(right) < len(left) {
+		return nil, -1, fmt.Errorf("cannot assign %d nodes to %d targets", len(left), len(right))
+	}
+	rightIndex := make(map[string]int)
+	for i, name := range right {
+		rightIndex[name] = i
+	}
+
+	// missing edges cost more than any complete assignment could, so they're only used if unavoidable
+	forbidden := 1
+	for _, name := range left {
+		for _, edge := range g.GetEdges(name) {
+			forbidden += 2 * nums.Abs(edge.Cost)
+		}
+	}
+
+	cost := make([][]int, len(left))
+	allowed := make([][]bool, len(left))
+	for i, name := range left {
+		cost[i] = make([]int, len(right))
+		allowed[i] = make([]bool, len(right))
+		for j := range cost[i] {
+			cost[i][j] = forbidden
+		}
+		for _, edge := range g.GetEdges(name) {
+			if j, ok := rightIndex[edge.Node]; ok && (!allowed[i][j] || edge.Cost < cost[i][j]) {
+				cost[i][j] = edge.Cost
+				allowed[i][j] = true
+			}
+		}
+	}
+
+	indexPairs, total := Hungarian(cost)
+	pairs := []types.Pair[string, string]{}
+	for _, pair := range indexPairs {
+		if !allowed[pair.First][pair.Second] {
+			return nil, -1, fmt.Errorf("no assignment covers every node")
+		}
+		pairs = append(pairs, types.Pair[string, string]{First: left[pair.First], Second: right[pair.Second]})
+	}
+
+	return pairs, total, nil
+}
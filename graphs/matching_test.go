@@ -0,0 +1,109 @@
+package graphs
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+func TestHopcroftKarp(t *testing.T) {
+	pairs := HopcroftKarp([][]bool{
+		{true, true, false},
+		{true, false, false},
+		{false, true, true},
+	})
+
+	test.AssertEqual(t, pairs, []types.Pair[int, int]{{First: 0, Second: 1}, {First: 1, Second: 0}, {First: 2, Second: 2}})
+
+	// two rows competing for one column
+	test.AssertEqual(t, len(HopcroftKarp([][]bool{{true}, {true}})), 1)
+	test.AssertEqual(t, len(HopcroftKarp([][]bool{})), 0)
+}
+
+func TestMaxBipartiteMatching(t *testing.T) {
+	// each rule can only be one of the fields its values fit
+	g, _ := NewGraph([]string{"class", "row", "seat", "0", "1", "2"}, map[string][]Edge{
+		"class": {{Node: "1", Cost: 1}, {Node: "2", Cost: 1}},
+		"row":   {{Node: "0", Cost: 1}, {Node: "1", Cost: 1}, {Node: "2", Cost: 1}},
+		"seat":  {{Node: "2", Cost: 1}},
+	})
+
+	pairs := g.MaxBipartiteMatching([]string{"class", "row", "seat"})
+	test.AssertEqual(t, pairs, []types.Pair[string, string]{
+		{First: "class", Second: "1"},
+		{First: "row", Second: "0"},
+		{First: "seat", Second: "2"},
+	})
+
+	// not everyone can be matched
+	g2, _ := NewGraph([]string{"a", "b", "x"}, map[string][]Edge{
+		"a": {{Node: "x", Cost: 1}},
+		"b": {{Node: "x", Cost: 1}},
+	})
+	test.AssertEqual(t, len(g2.MaxBipartiteMatching([]string{"a", "b"})), 1)
+}
+
+func TestHungarian(t *testing.T) {
+	pairs, cost := Hungarian([][]int{
+		{4, 1, 3},
+		{2, 0, 5},
+		{3, 2, 2},
+	})
+	test.AssertEqual(t, cost, 5)
+	test.AssertEqual(t, pairs, []types.Pair[int, int]{{First: 0, Second: 1}, {First: 1, Second: 0}, {First: 2, Second: 2}})
+
+	// more columns than rows
+	pairs, cost = Hungarian([][]int{
+		{10, 1, 7, 8},
+		{3, 9, 1, 6},
+	})
+	test.AssertEqual(t, cost, 2)
+	test.AssertEqual(t, pairs, []types.Pair[int, int]{{First: 0, Second: 1}, {First: 1, Second: 2}})
+
+	// more rows than columns
+	pairs, cost = Hungarian([][]int{
+		{5, 9},
+		{1, 8},
+		{7, 2},
+	})
+	test.AssertEqual(t, cost, 3)
+	test.AssertEqual(t, pairs, []types.Pair[int, int]{{First: 1, Second: 0}, {First: 2, Second: 1}})
+
+	// negative costs
+	_, cost = Hungarian([][]int{{-1, -5}, {-3, -2}})
+	test.AssertEqual(t, cost, -8)
+
+	pairs, cost = Hungarian([][]int{})
+	test.AssertEqual(t, len(pairs), 0)
+	test.AssertEqual(t, cost, 0)
+}
+
+func TestMinCostAssignment(t *testing.T) {
+	g, _ := NewGraph([]string{"alice", "bob", "carol", "cook", "drive", "paint"}, map[string][]Edge{
+		"alice": {{Node: "cook", Cost: 3}, {Node: "drive", Cost: 1}},
+		"bob":   {{Node: "drive", Cost: 2}, {Node: "paint", Cost: 8}},
+		"carol": {{Node: "cook", Cost: 4}, {Node: "paint", Cost: 5}},
+	})
+
+	pairs, cost, err := g.MinCostAssignment([]string{"alice", "bob", "carol"})
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, cost, 10)
+	test.AssertEqual(t, pairs, []types.Pair[string, string]{
+		{First: "alice", Second: "cook"},
+		{First: "bob", Second: "drive"},
+		{First: "carol", Second: "paint"},
+	})
+
+	// alice and carol both only want to drive
+	g2, _ := NewGraph([]string{"alice", "bob", "carol", "cook", "drive", "paint"}, map[string][]Edge{
+		"alice": {{Node: "drive", Cost: 1}},
+		"bob":   {{Node: "cook", Cost: -100}, {Node: "paint", Cost: 1}},
+		"carol": {{Node: "drive", Cost: 1}},
+	})
+
+	_, _, err = g2.MinCostAssignment([]string{"alice", "bob", "carol"})
+	test.AssertNotEqual(t, err, nil)
+	_, _, err = g2.MinCostAssignment([]string{"alice", "carol"})
+	test.AssertNotEqual(t, err, nil)
+}
@@ -0,0 +1,141 @@
+package graphs
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/jack-barr3tt/gostuff/queue"
+)
+
+// search runs A* from every source at once until a node satisfying goal is expanded.
+// Nodes the heuristic gives -1 for are never entered. Nodes are reopened if a cheaper
+// route to them turns up later, so the heuristic doesn't have to be consistent.
+// If the tracker stops the search, or nothing is found after it cut paths short, its error is returned.
+// Once negative edges have been seen, a route with more edges than there are known nodes is checked
+// for a negative cycle, which is returned as a *NegativeCycleError rather than searching forever.
+func (g Graph) search(tr *tracker, sources []string, goal func(n Node) bool, heuristic func(n Node) int) ([]string, int, error) {
+	pq := make(queue.PriorityQueue[string], 0)
+	heap.Init(&pq)
+
+	cameFrom := make(map[string]string)
+	costSoFar := make(map[string]int)
+	depth := make(map[string]int)
+	estimate := make(map[string]int)
+	closed := make(map[string]bool)
+
+	for _, source := range sources {
+		n, ok := g.At(source)
+		if !ok {
+			continue
+		}
+		costSoFar[source] = 0
+		depth[source] = 0
+		estimate[source] = heuristic(*n)
+		heap.Push(&pq, &queue.Item[string]{Value: source, Priority: estimate[source]})
+		tr.push(pq.Len())
+	}
+
+	for pq.Len() > 0 {
+		item := heap.Pop(&pq).(*queue.Item[string])
+		curr := item.Value
+		if item.Priority > costSoFar[curr]+estimate[curr] {
+			continue
+		}
+
+		currNode, _ := g.At(curr)
+		if goal(*currNode) {
+			return reconstructPath(cameFrom, curr), costSoFar[curr], nil
+		}
+
+		if err := tr.expand(*currNode, costSoFar[curr]); err != nil {
+			return nil, -1, err
+		}
+		if closed[curr] {
+			tr.stats.Reopened++
+		}
+		closed[curr] = true
+
+		for _, edge := range currNode.Adj {
+			newCost := costSoFar[curr] + edge.Cost
+			if oldCost, ok := costSoFar[edge.Node]; ok && newCost >= oldCost {
+				continue
+			}
+			if !tr.allow(depth[curr]+1, newCost) {
+				continue
+			}
+
+			h, ok := estimate[edge.Node]
+			if !ok {
+				h = heuristic(*g.nodeIds[edge.Node])
+				estimate[edge.Node] = h
+			}
+			if h == -1 {
+				continue
+			}
+
+			cameFrom[edge.Node] = curr
+			costSoFar[edge.Node] = newCost
+			depth[edge.Node] = depth[curr] + 1
+			tr.relax(curr, edge.Node, newCost)
+
+			if depth[edge.Node] >= len(g.nodeIds) && g.HasNegativeEdges() {
+				if cycle := cycleIn(cameFrom, edge.Node); cycle != nil {
+					return nil, -1, &NegativeCycleError{Cycle: cycle}
+				}
+			}
+			heap.Push(&pq, &queue.Item[string]{Value: edge.Node, Priority: newCost + h})
+			tr.push(pq.Len())
+		}
+	}
+
+	return nil, -1, tr.pruned
+}
+
+// cycleIn follows cameFrom back from a node until a node repeats, and returns that loop in path order.
+// A loop in the routes found while relaxing edges always has a negative cost. Returns nil if there is no loop.
+func cycleIn(cameFrom map[string]string, node string) []string {
+	seen := map[string]bool{}
+	for !seen[node] {
+		seen[node] = true
+		prev, ok := cameFrom[node]
+		if !ok {
+			return nil
+		}
+		node = prev
+	}
+
+	cycle := []string{node}
+	for curr := cameFrom[node]; curr != node; curr = cameFrom[curr] {
+		cycle = append([]string{curr}, cycle...)
+	}
+	return cycle
+}
+
+func reconstructPath(cameFrom map[string]string, current string) []string {
+	totalPath := []string{current}
+	ok := true
+	for {
+		current, ok = cameFrom[current]
+		if !ok {
+			break
+		}
+		totalPath = append([]string{current}, totalPath...)
+	}
+	return totalPath
+}
+
+// ShortestPathToAny returns the shortest path from source to the closest node satisfying goal.
+// This is useful for virtual graphs where the goal is a condition rather than a known node name.
+// Returns nil, -1 if no such node is reachable.
+func (g Graph) ShortestPathToAny(source string, goal func(n Node) bool) ([]string, int) {
+	path, cost, _ := g.search(newTracker(context.Background(), SearchLimits{}), []string{source}, goal, func(n Node) int { return 0 })
+	return path, cost
+}
+
+// MultiSourceShortestPath returns the shortest path from whichever of sources is closest
+// to a node satisfying goal. Sources that don't exist are ignored.
+// Returns nil, -1 if no such node is reachable.
+func (g Graph) MultiSourceShortestPath(sources []string, goal func(n Node) bool) ([]string, int) {
+	path, cost, _ := g.search(newTracker(context.Background(), SearchLimits{}), sources, goal, func(n Node) int { return 0 })
+	return path, cost
+}
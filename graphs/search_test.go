@@ -0,0 +1,107 @@
+package graphs
+
+import (
+	"fmt"
+	"testing"
+
+	stringstuff "github.com/jack-barr3tt/gostuff/strings"
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestShortestPathToAny(t *testing.T) {
+	// walk along a number line where the goal is any multiple of 7 above 20
+	g := NewVirtualGraph(func(n *Node) []Edge {
+		x := stringstuff.GetNum(n.Name)
+		return []Edge{
+			{Node: fmt.Sprint(x + 3), Cost: 1},
+			{Node: fmt.Sprint(x + 5), Cost: 1},
+		}
+	}, "0")
+
+	path, length := g.ShortestPathToAny("0", func(n Node) bool {
+		x := stringstuff.GetNum(n.Name)
+		return x > 20 && x%7 == 0
+	})
+
+	test.AssertEqual(t, length, 5)
+	test.AssertEqual(t, path[len(path)-1], "21")
+
+	_, length = g.ShortestPathToAny("nonexistent", func(n Node) bool { return true })
+	test.AssertEqual(t, length, -1)
+}
+
+func TestMultiSourceShortestPath(t *testing.T) {
+	g := textbookGraph()
+
+	path, length := g.MultiSourceShortestPath([]string{"A", "B"}, func(n Node) bool { return n.Name == "T" })
+	test.AssertEqual(t, path, []string{"A", "D", "T"})
+	test.AssertEqual(t, length, 7)
+
+	path, length = g.MultiSourceShortestPath([]string{"S", "nonexistent"}, func(n Node) bool { return n.Name == "T" })
+	test.AssertEqual(t, path, []string{"S", "C", "B", "D", "T"})
+	test.AssertEqual(t, length, 11)
+
+	_, length = g.MultiSourceShortestPath([]string{}, func(n Node) bool { return true })
+	test.AssertEqual(t, length, -1)
+}
+
+func TestBidirectionalShortestPath(t *testing.T) {
+	g := textbookGraph()
+
+	path, length := g.BidirectionalShortestPath("S", "T")
+	test.AssertEqual(t, path, []string{"S", "C", "B", "D", "T"})
+	test.AssertEqual(t, length, 11)
+
+	for _, from := range g.GetNodes() {
+		for _, to := range g.GetNodes() {
+			_, expected := g.ShortestPath(from, to, func(n Node) int { return 0 })
+			_, actual := g.BidirectionalShortestPath(from, to)
+			test.AssertEqual(t, actual, expected)
+		}
+	}
+
+	g2, _ := NewGraph([]string{"A", "B", "C"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}},
+	})
+
+	path2, length2 := g2.BidirectionalShortestPath("A", "C")
+	test.AssertEqual(t, path2 == nil, true)
+	test.AssertEqual(t, length2, -1)
+
+	_, length3 := g2.BidirectionalShortestPath("A", "nonexistent")
+	test.AssertEqual(t, length3, -1)
+}
+
+func TestBidirectionalBFS(t *testing.T) {
+	g := textbookGraph()
+
+	path, length := g.BidirectionalBFS("S", "T")
+	test.AssertEqual(t, length, 2)
+	test.AssertEqual(t, len(path), 3)
+
+	path, length = g.BidirectionalBFS("A", "C")
+	test.AssertEqual(t, length, 2)
+	test.AssertEqual(t, path[0], "A")
+	test.AssertEqual(t, path[2], "C")
+
+	path, length = g.BidirectionalBFS("A", "A")
+	test.AssertEqual(t, path, []string{"A"})
+	test.AssertEqual(t, length, 0)
+
+	g2, _ := NewGraph([]string{"A", "B", "C"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}},
+	})
+
+	_, length2 := g2.BidirectionalBFS("A", "C")
+	test.AssertEqual(t, length2, -1)
+
+	// the reversed index is reused between queries, and rebuilt once an edge is added
+	cached := g2.state.indexed
+	g2.BidirectionalBFS("B", "A")
+	test.AssertEqual(t, g2.state.indexed == cached, true)
+
+	g2.AddEdge("B", "C", 1)
+	path, length = g2.BidirectionalBFS("A", "C")
+	test.AssertEqual(t, path, []string{"A", "B", "C"})
+	test.AssertEqual(t, length, 2)
+}
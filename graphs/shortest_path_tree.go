@@ -0,0 +1,222 @@
+package graphs
+
+import (
+	"container/heap"
+
+	"github.com/jack-barr3tt/gostuff/queue"
+	"github.com/jack-barr3tt/gostuff/set"
+)
+
+// ShortestPathTree is the result of a single source Dijkstra search.
+// Preds holds every predecessor of a node that lies on some shortest path to it,
+// so following them back from any node gives every shortest path from Source.
+type ShortestPathTree struct {
+	Source string
+	Dist   map[string]int
+	Preds  map[string][]string
+}
+
+// ShortestPathTree returns the distance from source to every reachable node along with
+// all equal cost predecessors. Edge costs must not be negative and zero cost cycles are not allowed.
+// On a virtual graph the search only ends once the generator stops producing new nodes.
+func (g Graph) ShortestPathTree(source string) ShortestPathTree {
+	return g.shortestPathTree(source, nil)
+}
+
+// shortestPathTree runs Dijkstra from source. If done is given, the search stops once
+// every node as close as the first node satisfying done has been settled.
+func (g Graph) shortestPathTree(source string, done func(n Node) bool) ShortestPathTree {
+	tree := ShortestPathTree{Source: source, Dist: make(map[string]int), Preds: make(map[string][]string)}
+	if _, ok := g.At(source); !ok {
+		return tree
+	}
+
+	pq := make(queue.PriorityQueue[string], 0)
+	heap.Init(&pq)
+	heap.Push(&pq, &queue.Item[string]{Value: source, Priority: 0})
+	tree.Dist[source] = 0
+
+	settled := make(map[string]bool)
+	stopAt := -1
+
+	for pq.Len() > 0 {
+		item := heap.Pop(&pq).(*queue.Item[string])
+		curr := item.Value
+		if settled[curr] || item.Priority > tree.Dist[curr] {
+			continue
+		}
+		if stopAt != -1 && item.Priority > stopAt {
+			break
+		}
+		settled[curr] = true
+
+		currNode, _ := g.At(curr)
+		if stopAt == -1 && done != nil && done(*currNode) {
+			stopAt = item.Priority
+		}
+
+		for _, edge := range currNode.Adj {
+			newCost := tree.Dist[curr] + edge.Cost
+			oldCost, seen := tree.Dist[edge.Node]
+
+			if !seen || newCost < oldCost {
+				tree.Dist[edge.Node] = newCost
+				tree.Preds[edge.Node] = []string{curr}
+				heap.Push(&pq, &queue.Item[string]{Value: edge.Node, Priority: newCost})
+			} else if newCost == oldCost && edge.Node != source {
+				preds := tree.Preds[edge.Node]
+				if preds[len(preds)-1] != curr {
+					tree.Preds[edge.Node] = append(preds, curr)
+				}
+			}
+		}
+	}
+
+	// Anything left unsettled was only seen, so its distance is not final
+	for name := range tree.Dist {
+		if !settled[name] {
+			delete(tree.Dist, name)
+			delete(tree.Preds, name)
+		}
+	}
+
+	return tree
+}
+
+// Distance returns the shortest distance from the source to a node
+func (t ShortestPathTree) Distance(node string) (int, bool) {
+	d, ok := t.Dist[node]
+	return d, ok
+}
+
+// Path returns one shortest path from the source to target, or nil if it is unreachable
+func (t ShortestPathTree) Path(target string) []string {
+	if _, ok := t.Dist[target]; !ok {
+		return nil
+	}
+
+	path := []string{target}
+	for current := target; current != t.Source; {
+		current = t.Preds[current][0]
+		path = append([]string{current}, path...)
+	}
+	return path
+}
+
+// WalkPaths calls visit with every shortest path from the source to target, one at a time.
+// The path slice is reused between calls so it must be copied if kept.
+// Returning false from visit stops the walk.
+func (t ShortestPathTree) WalkPaths(target string, visit func(path []string) bool) {
+	if _, ok := t.Dist[target]; !ok {
+		return
+	}
+
+	reversed := []string{}
+	path := []string{}
+
+	var dfs func(node string) bool
+	dfs = func(node string) bool {
+		reversed = append(reversed, node)
+		defer func() { reversed = reversed[:len(reversed)-1] }()
+
+		if node == t.Source {
+			path = path[:0]
+			for i := len(reversed) - 1; i >= 0; i-- {
+				path = append(path, reversed[i])
+			}
+			return visit(path)
+		}
+
+		for _, prev := range t.Preds[node] {
+			if !dfs(prev) {
+				return false
+			}
+		}
+		return true
+	}
+
+	dfs(target)
+}
+
+// Paths returns every shortest path from the source to target
+func (t ShortestPathTree) Paths(target string) [][]string {
+	paths := [][]string{}
+	t.WalkPaths(target, func(path []string) bool {
+		pathCopy := make([]string, len(path))
+		copy(pathCopy, path)
+		paths = append(paths, pathCopy)
+		return true
+	})
+	return paths
+}
+
+// CountPaths returns the number of distinct shortest paths from the source to target
+// without building any of them
+func (t ShortestPathTree) CountPaths(target string) int {
+	if _, ok := t.Dist[target]; !ok {
+		return 0
+	}
+
+	counts := map[string]int{t.Source: 1}
+
+	var count func(node string) int
+	count = func(node string) int {
+		if c, ok := counts[node]; ok {
+			return c
+		}
+		c := 0
+		for _, prev := range t.Preds[node] {
+			c += count(prev)
+		}
+		counts[node] = c
+		return c
+	}
+
+	return count(target)
+}
+
+// NodesOnPaths returns every node that lies on at least one shortest path from the source
+// to the closest of the given targets, without enumerating the paths.
+// Targets further away than the closest reachable one are ignored.
+func (t ShortestPathTree) NodesOnPaths(targets ...string) set.Set[string] {
+	nodes := set.New[string]()
+
+	best := -1
+	for _, target := range targets {
+		if d, ok := t.Dist[target]; ok && (best == -1 || d < best) {
+			best = d
+		}
+	}
+
+	stack := []string{}
+	for _, target := range targets {
+		if d, ok := t.Dist[target]; ok && d == best && !nodes.Has(target) {
+			nodes.Add(target)
+			stack = append(stack, target)
+		}
+	}
+
+	for len(stack) > 0 {
+		curr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, prev := range t.Preds[curr] {
+			if !nodes.Has(prev) {
+				nodes.Add(prev)
+				stack = append(stack, prev)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// CountShortestPaths returns the number of distinct shortest paths from source to target.
+// Paths are counted over the predecessor DAG so none of them are built.
+func (g Graph) CountShortestPaths(source, target string) int {
+	return g.shortestPathTree(source, func(n Node) bool { return n.Name == target }).CountPaths(target)
+}
+
+// NodesOnAnyShortestPath returns every node that lies on at least one shortest path from source to target
+func (g Graph) NodesOnAnyShortestPath(source, target string) set.Set[string] {
+	return g.shortestPathTree(source, func(n Node) bool { return n.Name == target }).NodesOnPaths(target)
+}
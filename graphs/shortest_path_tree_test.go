@@ -0,0 +1,106 @@
+package graphs
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestShortestPathTree(t *testing.T) {
+	g, _ := NewGraph([]string{"A", "B", "C", "D", "E", "F"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}, {Node: "C", Cost: 2}, {Node: "D", Cost: 1}},
+		"B": {{Node: "A", Cost: 1}, {Node: "E", Cost: 2}},
+		"C": {{Node: "A", Cost: 2}, {Node: "D", Cost: 1}, {Node: "E", Cost: 1}},
+		"D": {{Node: "A", Cost: 1}, {Node: "C", Cost: 1}, {Node: "E", Cost: 3}},
+		"E": {{Node: "B", Cost: 2}, {Node: "C", Cost: 1}, {Node: "D", Cost: 3}},
+	})
+
+	tree := g.ShortestPathTree("A")
+
+	test.AssertEqual(t, tree.Dist, map[string]int{"A": 0, "B": 1, "C": 2, "D": 1, "E": 3})
+	test.AssertSlicesEqual(t, tree.Preds["C"], []string{"A", "D"})
+	test.AssertSlicesEqual(t, tree.Preds["E"], []string{"B", "C"})
+
+	dist, ok := tree.Distance("E")
+	test.AssertEqual(t, dist, 3)
+	test.AssertEqual(t, ok, true)
+
+	_, ok = tree.Distance("F")
+	test.AssertEqual(t, ok, false)
+
+	test.AssertEqual(t, len(tree.Path("E")), 3)
+	test.AssertEqual(t, tree.Path("F") == nil, true)
+
+	test.AssertSlicesEqual(t, tree.Paths("E"), [][]string{{"A", "B", "E"}, {"A", "C", "E"}, {"A", "D", "C", "E"}})
+	test.AssertEqual(t, tree.Paths("A"), [][]string{{"A"}})
+
+	test.AssertEqual(t, tree.CountPaths("E"), 3)
+	test.AssertEqual(t, tree.CountPaths("C"), 2)
+	test.AssertEqual(t, tree.CountPaths("A"), 1)
+	test.AssertEqual(t, tree.CountPaths("F"), 0)
+
+	// Test stopping a walk early
+	walked := 0
+	tree.WalkPaths("E", func(path []string) bool {
+		walked++
+		return false
+	})
+	test.AssertEqual(t, walked, 1)
+}
+
+func TestShortestPathTreeCountsWithoutEnumerating(t *testing.T) {
+	// a ladder of diamonds doubles the number of shortest paths at every rung
+	nodes := []string{"0"}
+	edges := map[string][]Edge{}
+	prev := "0"
+	for i := 1; i <= 40; i++ {
+		left, right, next := prev+"l", prev+"r", prev+"n"
+		nodes = append(nodes, left, right, next)
+		edges[prev] = []Edge{{Node: left, Cost: 1}, {Node: right, Cost: 1}}
+		edges[left] = []Edge{{Node: next, Cost: 1}}
+		edges[right] = []Edge{{Node: next, Cost: 1}}
+		prev = next
+	}
+
+	g, _ := NewGraph(nodes, edges)
+	tree := g.ShortestPathTree("0")
+
+	test.AssertEqual(t, tree.CountPaths(prev), 1<<40)
+	test.AssertEqual(t, tree.Dist[prev], 80)
+}
+
+func TestNodesOnPaths(t *testing.T) {
+	g, _ := NewGraph([]string{"A", "B", "C", "D", "E", "F"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}, {Node: "C", Cost: 2}, {Node: "D", Cost: 1}},
+		"B": {{Node: "A", Cost: 1}, {Node: "E", Cost: 2}},
+		"C": {{Node: "A", Cost: 2}, {Node: "D", Cost: 1}, {Node: "E", Cost: 1}, {Node: "F", Cost: 1}},
+		"D": {{Node: "A", Cost: 1}, {Node: "C", Cost: 1}, {Node: "E", Cost: 3}},
+		"E": {{Node: "B", Cost: 2}, {Node: "C", Cost: 1}, {Node: "D", Cost: 3}},
+	})
+
+	tree := g.ShortestPathTree("A")
+
+	test.AssertSlicesEqual(t, tree.NodesOnPaths("E").ToSlice(), []string{"A", "B", "C", "D", "E"})
+	test.AssertSlicesEqual(t, tree.NodesOnPaths("C").ToSlice(), []string{"A", "C", "D"})
+
+	// only the closest targets count, so E is ignored when B is also a target
+	test.AssertSlicesEqual(t, tree.NodesOnPaths("B", "E").ToSlice(), []string{"A", "B"})
+	test.AssertSlicesEqual(t, tree.NodesOnPaths("E", "F").ToSlice(), []string{"A", "B", "C", "D", "E", "F"})
+
+	test.AssertEqual(t, tree.NodesOnPaths("nonexistent").Size(), 0)
+}
+
+func TestCountShortestPaths(t *testing.T) {
+	g, _ := NewGraph([]string{"A", "B", "C", "D", "E"}, map[string][]Edge{
+		"A": {{Node: "B", Cost: 1}, {Node: "C", Cost: 2}, {Node: "D", Cost: 1}},
+		"B": {{Node: "A", Cost: 1}, {Node: "E", Cost: 2}},
+		"C": {{Node: "A", Cost: 2}, {Node: "D", Cost: 1}, {Node: "E", Cost: 1}},
+		"D": {{Node: "A", Cost: 1}, {Node: "C", Cost: 1}, {Node: "E", Cost: 3}},
+		"E": {{Node: "B", Cost: 2}, {Node: "C", Cost: 1}, {Node: "D", Cost: 3}},
+	})
+
+	test.AssertEqual(t, g.CountShortestPaths("A", "E"), 3)
+	test.AssertEqual(t, g.CountShortestPaths("A", "nonexistent"), 0)
+	test.AssertSlicesEqual(t, g.NodesOnAnyShortestPath("A", "E").ToSlice(), []string{"A", "B", "C", "D", "E"})
+	test.AssertSlicesEqual(t, g.NodesOnAnyShortestPath("A", "C").ToSlice(), []string{"A", "C", "D"})
+}
@@ -0,0 +1,37 @@
+package graphs
+
+// SearchStats counts the work done by a search, which shows how well a heuristic is guiding it.
+// Depth first searches have no frontier, so they only count expansions.
+type SearchStats struct {
+	// Expanded is the number of nodes whose edges were followed
+	Expanded int
+	// Pushes is the number of entries added to the frontier
+	Pushes int
+	// MaxFrontier is the largest the frontier got, including stale entries
+	MaxFrontier int
+	// Reopened is the number of expansions of a node that had already been expanded,
+	// which only happens when the heuristic is inconsistent or edges are negative
+	Reopened int
+}
+
+// SearchHooks are called as a search runs, e.g. to log it or animate it over a maze.
+// Either can be nil. Depth first searches only call OnExpand.
+type SearchHooks struct {
+	// OnExpand is called with each node as its edges are about to be followed, and its cost from the source
+	OnExpand func(n Node, cost int)
+	// OnRelax is called whenever a cheaper route to a node is found, with the new cost
+	OnRelax func(from, to string, cost int)
+}
+
+func (tr *tracker) push(frontier int) {
+	tr.stats.Pushes++
+	if frontier > tr.stats.MaxFrontier {
+		tr.stats.MaxFrontier = frontier
+	}
+}
+
+func (tr *tracker) relax(from, to string, cost int) {
+	if tr.hooks.OnRelax != nil {
+		tr.hooks.OnRelax(from, to, cost)
+	}
+}
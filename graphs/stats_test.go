@@ -0,0 +1,116 @@
+package graphs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/nums"
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+// an open 20x20 grid, with nodes named "x,y"
+func openGrid() Graph {
+	return NewVirtualGraph(func(n *Node) []Edge {
+		var x, y int
+		fmt.Sscanf(n.Name, "%d,%d", &x, &y)
+		edges := []Edge{}
+		for _, d := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+			nx, ny := x+d[0], y+d[1]
+			if nx >= 0 && ny >= 0 && nx < 20 && ny < 20 {
+				edges = append(edges, Edge{Node: fmt.Sprintf("%d,%d", nx, ny), Cost: 1})
+			}
+		}
+		return edges
+	}, "0,0")
+}
+
+func TestSearchStats(t *testing.T) {
+	manhattan := func(n Node) int {
+		var x, y int
+		fmt.Sscanf(n.Name, "%d,%d", &x, &y)
+		return nums.Abs(19-x) + nums.Abs(19-y)
+	}
+
+	var blind, guided SearchStats
+	_, blindCost, _ := openGrid().ShortestPathContext(context.Background(), "0,0", "19,19", func(n Node) int { return 0 }, SearchLimits{Stats: &blind})
+	_, guidedCost, _ := openGrid().ShortestPathContext(context.Background(), "0,0", "19,19", manhattan, SearchLimits{Stats: &guided})
+
+	// a good heuristic finds the same answer with much less work
+	test.AssertEqual(t, blindCost, 38)
+	test.AssertEqual(t, guidedCost, 38)
+	test.AssertEqual(t, blind.Expanded, 399)
+	test.AssertEqual(t, guided.Expanded < blind.Expanded/2, true)
+	test.AssertEqual(t, guided.Pushes >= guided.Expanded, true)
+	test.AssertEqual(t, guided.MaxFrontier > 0, true)
+	test.AssertEqual(t, blind.Reopened, 0)
+
+	// the hooks see every expansion and relaxation
+	expanded, relaxed := 0, 0
+	var stats SearchStats
+	openGrid().ShortestPathContext(context.Background(), "0,0", "19,19", manhattan, SearchLimits{
+		Hooks: SearchHooks{
+			OnExpand: func(n Node, cost int) { expanded++ },
+			OnRelax:  func(from, to string, cost int) { relaxed++ },
+		},
+		Stats: &stats,
+	})
+	test.AssertEqual(t, expanded, stats.Expanded)
+	test.AssertEqual(t, relaxed, stats.Pushes-1)
+
+	// stats are still reported when a limit stops the search
+	_, _, err := openGrid().ShortestPathContext(context.Background(), "0,0", "19,19", manhattan, SearchLimits{MaxExpanded: 5, Stats: &stats})
+	test.AssertEqual(t, errors.Is(err, ErrExpansionLimit), true)
+	test.AssertEqual(t, stats.Expanded, 5)
+}
+
+func TestSearchStatsReopened(t *testing.T) {
+	// the heuristic never overestimates but is inconsistent, so B is expanded twice
+	g, _ := NewGraph([]string{"S", "A", "B", "G"}, map[string][]Edge{
+		"S": {{Node: "A", Cost: 1}, {Node: "B", Cost: 3}},
+		"A": {{Node: "B", Cost: 1}},
+		"B": {{Node: "G", Cost: 5}},
+	})
+	h := map[string]int{"S": 0, "A": 5, "B": 0, "G": 0}
+
+	order := []string{}
+	var stats SearchStats
+	path, cost, _ := g.ShortestPathContext(context.Background(), "S", "G", func(n Node) int { return h[n.Name] }, SearchLimits{
+		Hooks: SearchHooks{OnExpand: func(n Node, cost int) { order = append(order, fmt.Sprint(n.Name, cost)) }},
+		Stats: &stats,
+	})
+
+	test.AssertEqual(t, path, []string{"S", "A", "B", "G"})
+	test.AssertEqual(t, cost, 7)
+	test.AssertEqual(t, stats.Reopened, 1)
+	test.AssertEqual(t, order, []string{"S0", "B3", "A1", "B2"})
+}
+
+func TestSearchStatsOtherSearches(t *testing.T) {
+	// SPFA is used for negative edges, and reports its work the same way
+	relaxed := 0
+	var stats SearchStats
+	path, _, err := negativeGraph().ShortestPathContext(context.Background(), "A", "D", func(n Node) int { return 0 }, SearchLimits{
+		Hooks: SearchHooks{OnRelax: func(from, to string, cost int) { relaxed++ }},
+		Stats: &stats,
+	})
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, path, []string{"A", "C", "B", "D"})
+	test.AssertEqual(t, stats.Expanded > 0, true)
+	test.AssertEqual(t, stats.Pushes, stats.Expanded)
+	test.AssertEqual(t, relaxed >= stats.Pushes-1, true)
+
+	// depth first searches count the nodes they expand
+	expanded := 0
+	paths, _ := textbookGraph().AllPathsContext(context.Background(), "S", "T", SearchLimits{
+		Hooks: SearchHooks{OnExpand: func(n Node, cost int) { expanded++ }},
+		Stats: &stats,
+	})
+	test.AssertEqual(t, len(paths) > 0, true)
+	test.AssertEqual(t, stats.Expanded, expanded)
+
+	count, _ := textbookGraph().CountPathsContext(context.Background(), "S", "T", SearchLimits{Stats: &stats})
+	test.AssertEqual(t, count, textbookGraph().CountPaths("S", "T"))
+	test.AssertEqual(t, stats.Expanded > 0, true)
+}
@@ -0,0 +1,288 @@
+package graphs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jack-barr3tt/gostuff/nums"
+)
+
+// eulerEdge is one traversable edge in an Eulerian search, shared by both ends when undirected
+type eulerEdge struct {
+	from, to int
+}
+
+// eulerEdges lists the edges of the indexed graph to be walked. When undirected, an edge
+// and its reverse are taken as the same edge, so graphs storing both directions work either way.
+func (ig indexedGraph) eulerEdges(directed bool) []eulerEdge {
+	edges := []eulerEdge{}
+	if directed {
+		for from, adj := range ig.adj {
+			for _, e := range adj {
+				edges = append(edges, eulerEdge{from, e.to})
+			}
+		}
+		return edges
+	}
+
+	counts := map[eulerEdge]int{}
+	for from, adj := range ig.adj {
+		for _, e := range adj {
+			counts[eulerEdge{from, e.to}]++
+		}
+	}
+
+	handled := map[eulerEdge]bool{}
+	for from, adj := range ig.adj {
+		for _, e := range adj {
+			lo, hi := from, e.to
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			key := eulerEdge{lo, hi}
+			if handled[key] {
+				continue
+			}
+			handled[key] = true
+
+			for i := 0; i < nums.Max(counts[key], counts[eulerEdge{hi, lo}]); i++ {
+				edges = append(edges, key)
+			}
+		}
+	}
+	return edges
+}
+
+// eulerian walks every edge exactly once with Hierholzer's algorithm
+func (g Graph) eulerian(directed, circuit bool) ([]string, error) {
+	ig := g.indexAll()
+	edges := ig.eulerEdges(directed)
+	if len(edges) == 0 {
+		return nil, fmt.Errorf("graph has no edges")
+	}
+
+	n := len(ig.names)
+	incident := make([][]int, n)
+	balance := make([]int, n)
+	for id, e := range edges {
+		incident[e.from] = append(incident[e.from], id)
+		if directed {
+			balance[e.from]++
+			balance[e.to]--
+		} else {
+			if e.from != e.to {
+				incident[e.to] = append(incident[e.to], id)
+			}
+			balance[e.from]++
+			balance[e.to]++
+		}
+	}
+
+	// the other end of an edge leaving node
+	other := func(id, node int) int {
+		if edges[id].from == node {
+			return edges[id].to
+		}
+		return edges[id].from
+	}
+
+	// visit neighbours in name order so the result is reproducible
+	for node := range incident {
+		sort.SliceStable(incident[node], func(a, b int) bool {
+			return other(incident[node][a], node) < other(incident[node][b], node)
+		})
+	}
+
+	// start at the first node with edges, unless the walk has to start at an unbalanced one
+	start, forced, odd := -1, -1, 0
+	for node := 0; node < n; node++ {
+		if directed && nums.Abs(balance[node]) > 1 {
+			return nil, fmt.Errorf("%s has %d more edges out than in", ig.names[node], balance[node])
+		}
+		if start == -1 && len(incident[node]) > 0 {
+			start = node
+		}
+		if (directed && balance[node] != 0) || (!directed && balance[node]%2 != 0) {
+			odd++
+			if forced == -1 && (!directed || balance[node] == 1) {
+				forced = node
+			}
+		}
+	}
+	if forced != -1 {
+		start = forced
+	}
+
+	if circuit && odd != 0 {
+		return nil, fmt.Errorf("graph has no Eulerian circuit: %d nodes are unbalanced", odd)
+	}
+	if odd > 2 {
+		return nil, fmt.Errorf("graph has no Eulerian path: %d nodes are unbalanced", odd)
+	}
+
+	used := make([]bool, len(edges))
+	next := make([]int, n)
+	stack := []int{start}
+	order := []int{}
+
+	for len(stack) > 0 {
+		curr := stack[len(stack)-1]
+		for next[curr] < len(incident[curr]) && used[incident[curr][next[curr]]] {
+			next[curr]++
+		}
+		if next[curr] == len(incident[curr]) {
+			order = append(order, curr)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		id := incident[curr][next[curr]]
+		used[id] = true
+		stack = append(stack, other(id, curr))
+	}
+
+	if len(order) != len(edges)+1 {
+		return nil, fmt.Errorf("graph edges are not all connected")
+	}
+
+	path := make([]string, len(order))
+	for i, node := range order {
+		path[len(order)-1-i] = ig.names[node]
+	}
+	return path, nil
+}
+
+// EulerianPath returns a walk that uses every edge exactly once, as the list of nodes visited.
+// If directed is false edges can be walked either way, and an edge stored in both directions counts once.
+// Like AllPairsShortestPaths it uses the cached index described on Graph.
+// Returns an error if no such walk exists.
+func (g Graph) EulerianPath(directed bool) ([]string, error) {
+	return g.eulerian(directed, false)
+}
+
+// EulerianCircuit returns a walk that uses every edge exactly once and ends where it started
+func (g Graph) EulerianCircuit(directed bool) ([]string, error) {
+	return g.eulerian(directed, true)
+}
+
+// MaxHeldKarpNodes is the most nodes HeldKarp will take. Its tables hold n * 2^n entries,
+// which is already around 200MB at this size.
+const MaxHeldKarpNodes = 20
+
+// HeldKarp finds the cheapest order to visit every node of a distance matrix once, using a bitmask DP.
+// Entries of Infinity mean there is no way between two nodes. If start is -1 the path may start anywhere.
+// If cycle is true the tour must also return to start (node 0 if start is -1), and the cost includes that step.
+// Returns nil, -1 if no such order exists, or if there are more than MaxHeldKarpNodes nodes.
+func HeldKarp(dist [][]int, start int, cycle bool) ([]int, int) {
+	n := len(dist)
+	if n == 0 {
+		return []int{}, 0
+	}
+	if n > MaxHeldKarpNodes {
+		return nil, -1
+	}
+	if cycle && start == -1 {
+		start = 0
+	}
+
+	// best[mask*n+j] is the cheapest way to visit the nodes in mask, ending at j,
+	// and prev[mask*n+j] is the node visited before j on that way
+	full := 1<<n - 1
+	best := make([]int, (full+1)*n)
+	prev := make([]int8, (full+1)*n)
+	for i := range best {
+		best[i] = Infinity
+		prev[i] = -1
+	}
+	for j := 0; j < n; j++ {
+		if start == -1 || start == j {
+			best[(1<<j)*n+j] = 0
+		}
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		for j := 0; j < n; j++ {
+			curr := best[mask*n+j]
+			if curr == Infinity {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				if mask&(1<<k) != 0 || dist[j][k] == Infinity {
+					continue
+				}
+				next := (mask|1<<k)*n + k
+				if cost := curr + dist[j][k]; cost < best[next] {
+					best[next] = cost
+					prev[next] = int8(j)
+				}
+			}
+		}
+	}
+
+	end, total := -1, Infinity
+	for j := 0; j < n; j++ {
+		cost := best[full*n+j]
+		if cost == Infinity {
+			continue
+		}
+		if cycle {
+			if dist[j][start] == Infinity {
+				continue
+			}
+			cost += dist[j][start]
+		}
+		if cost < total {
+			end, total = j, cost
+		}
+	}
+	if end == -1 {
+		return nil, -1
+	}
+
+	order := make([]int, n)
+	for mask, j, i := full, end, n-1; j != -1; i-- {
+		order[i] = j
+		mask, j = mask&^(1<<j), int(prev[mask*n+j])
+	}
+	return order, total
+}
+
+// ShortestTour returns the cheapest order to visit every one of nodes, moving between them by
+// shortest paths, along with its cost. If start is empty the tour may start at any of them.
+// If cycle is true the tour returns to its start (the first of nodes if start is empty).
+// Returns nil, -1 if a node is unknown, no such tour exists or there are more than MaxHeldKarpNodes nodes.
+func (d DistanceMatrix) ShortestTour(nodes []string, start string, cycle bool) ([]string, int) {
+	indices := make([]int, len(nodes))
+	startAt := -1
+	for i, name := range nodes {
+		idx, ok := d.index[name]
+		if !ok {
+			return nil, -1
+		}
+		indices[i] = idx
+		if name == start {
+			startAt = i
+		}
+	}
+	if start != "" && startAt == -1 {
+		return nil, -1
+	}
+
+	dist := make([][]int, len(nodes))
+	for i, from := range indices {
+		dist[i] = make([]int, len(nodes))
+		for j, to := range indices {
+			dist[i][j] = d.Dist[from][to]
+		}
+	}
+
+	order, cost := HeldKarp(dist, startAt, cycle)
+	if order == nil {
+		return nil, -1
+	}
+
+	tour := make([]string, len(order))
+	for i, idx := range order {
+		tour[i] = nodes[idx]
+	}
+	return tour, cost
+}
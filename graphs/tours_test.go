@@ -0,0 +1,132 @@
+package graphs
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestEulerianPath(t *testing.T) {
+	// the house of Nikolaus can be drawn without lifting the pen, starting at one of the bottom corners
+	house, _ := ParseAdjacencyList(`A <-> B C D
+B <-> C D
+C <-> D E
+D <-> E`)
+
+	path, err := house.EulerianPath(false)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, len(path), 9)
+	test.AssertEqual(t, path[0] == "A" || path[0] == "B", true)
+
+	// but it has no circuit
+	_, err = house.EulerianCircuit(false)
+	test.AssertNotEqual(t, err, nil)
+
+	// the bridges of Konigsberg have no path at all
+	konigsberg, _ := ParseAdjacencyList(`N: I I E
+S: I I E
+E: I`)
+	_, err = konigsberg.EulerianPath(false)
+	test.AssertNotEqual(t, err, nil)
+
+	// directed edges are only walked forwards
+	g, _ := ParseAdjacencyList(`A -> B
+B -> C
+C -> A D`)
+	path, err = g.EulerianPath(true)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, path, []string{"C", "A", "B", "C", "D"})
+
+	_, err = g.EulerianCircuit(true)
+	test.AssertNotEqual(t, err, nil)
+
+	// two separate loops can't be joined
+	split, _ := ParseAdjacencyList(`A -> B
+B -> A
+C -> D
+D -> C`)
+	_, err = split.EulerianCircuit(true)
+	test.AssertNotEqual(t, err, nil)
+
+	_, err = NewEmptyGraph().EulerianPath(true)
+	test.AssertNotEqual(t, err, nil)
+}
+
+func TestEulerianCircuit(t *testing.T) {
+	g, _ := ParseAdjacencyList(`A -> B
+B -> C D
+C -> A
+D -> B`)
+
+	path, err := g.EulerianCircuit(true)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, path, []string{"A", "B", "D", "B", "C", "A"})
+
+	// a square walked as an undirected graph
+	square, _ := ParseAdjacencyList(`A: B
+B: C
+C: D
+D: A`)
+	path, err = square.EulerianCircuit(false)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, path, []string{"A", "B", "C", "D", "A"})
+}
+
+func TestHeldKarp(t *testing.T) {
+	dist := [][]int{
+		{0, 2, 9, 10},
+		{1, 0, 6, 4},
+		{15, 7, 0, 8},
+		{6, 3, 12, 0},
+	}
+
+	order, cost := HeldKarp(dist, 0, true)
+	test.AssertEqual(t, order, []int{0, 2, 3, 1})
+	test.AssertEqual(t, cost, 21)
+
+	order, cost = HeldKarp(dist, -1, false)
+	test.AssertEqual(t, order, []int{2, 3, 1, 0})
+	test.AssertEqual(t, cost, 12)
+
+	// no way back to the start
+	_, cost = HeldKarp([][]int{{0, 1}, {Infinity, 0}}, 0, true)
+	test.AssertEqual(t, cost, -1)
+
+	// too many nodes are rejected rather than running out of memory
+	big := make([][]int, 64)
+	for i := range big {
+		big[i] = make([]int, 64)
+	}
+	order, cost = HeldKarp(big, -1, false)
+	test.AssertEqual(t, order == nil, true)
+	test.AssertEqual(t, cost, -1)
+}
+
+func TestShortestTour(t *testing.T) {
+	// From Advent of Code 2015 Day 9 example
+	g, _ := NewGraph([]string{"London", "Dublin", "Belfast"}, map[string][]Edge{
+		"London":  {{Node: "Dublin", Cost: 464}, {Node: "Belfast", Cost: 518}},
+		"Dublin":  {{Node: "London", Cost: 464}, {Node: "Belfast", Cost: 141}},
+		"Belfast": {{Node: "London", Cost: 518}, {Node: "Dublin", Cost: 141}},
+	})
+	d, _ := g.AllPairsShortestPaths()
+
+	tour, cost := d.ShortestTour(g.GetNodesSorted(), "", false)
+	test.AssertEqual(t, cost, 605)
+	test.AssertEqual(t, tour, []string{"London", "Dublin", "Belfast"})
+
+	tour, cost = d.ShortestTour([]string{"London", "Dublin", "Belfast"}, "Dublin", true)
+	test.AssertEqual(t, cost, 1123)
+	test.AssertEqual(t, tour[0], "Dublin")
+
+	// tours can pass through nodes that aren't visited
+	g2, _ := ParseAdjacencyList(`A <-> hub
+B <-> hub
+C <-> hub`)
+	d2, _ := g2.AllPairsShortestPaths()
+	_, cost = d2.ShortestTour([]string{"A", "B", "C"}, "A", true)
+	test.AssertEqual(t, cost, 6)
+
+	_, cost = d2.ShortestTour([]string{"A", "Z"}, "", false)
+	test.AssertEqual(t, cost, -1)
+}
@@ -1,5 +1,10 @@
 package maps
 
+import (
+	"cmp"
+	"slices"
+)
+
 func Keys[K comparable, V any](m map[K]V) []K {
 	keys := make([]K, 0)
 	for k := range m {
@@ -8,6 +13,13 @@ func Keys[K comparable, V any](m map[K]V) []K {
 	return keys
 }
 
+// SortedKeys returns the keys of a map in ascending order, so iteration is reproducible
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
 func Values[K comparable, V any](m map[K]V) []V {
 	values := make([]V, 0)
 	for _, v := range m {
@@ -20,6 +20,12 @@ func TestKeys(t *testing.T) {
 	test.AssertEqual(t, result4, []int{})
 }
 
+func TestSortedKeys(t *testing.T) {
+	test.AssertEqual(t, SortedKeys(map[string]int{"c": 1, "a": 2, "b": 3}), []string{"a", "b", "c"})
+	test.AssertEqual(t, SortedKeys(map[int]string{3: "a", -1: "b", 2: "c"}), []int{-1, 2, 3})
+	test.AssertEqual(t, SortedKeys(map[int]string{}), []int{})
+}
+
 func TestValues(t *testing.T) {
 	result1 := Values(map[string]int{"a": 1, "b": 2})
 	test.AssertSlicesEqual(t, result1, []int{1, 2})
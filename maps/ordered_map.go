@@ -0,0 +1,69 @@
+package maps
+
+// OrderedMap is a map that remembers the order keys were first inserted in
+type OrderedMap[K comparable, V any] struct {
+	keys   []K
+	values map[K]V
+}
+
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{keys: []K{}, values: make(map[K]V)}
+}
+
+// Set adds or updates a key. Updating a key keeps its original position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.values[key]
+	return ok
+}
+
+func (m *OrderedMap[K, V]) Delete(key K) {
+	if _, exists := m.values[key]; !exists {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, len(m.keys))
+	copy(keys, m.keys)
+	return keys
+}
+
+// Values returns the values in the insertion order of their keys
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, len(m.keys))
+	for i, k := range m.keys {
+		values[i] = m.values[k]
+	}
+	return values
+}
+
+// Each calls fn with every entry in insertion order
+func (m *OrderedMap[K, V]) Each(fn func(K, V)) {
+	for _, k := range m.keys {
+		fn(k, m.values[k])
+	}
+}
@@ -0,0 +1,44 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	test.AssertEqual(t, m.Len(), 0)
+	test.AssertEqual(t, m.Keys(), []string{})
+
+	m.Set("c", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+	m.Set("a", 4)
+
+	test.AssertEqual(t, m.Len(), 3)
+	test.AssertEqual(t, m.Keys(), []string{"c", "a", "b"})
+	test.AssertEqual(t, m.Values(), []int{1, 4, 3})
+
+	v, ok := m.Get("a")
+	test.AssertEqual(t, v, 4)
+	test.AssertEqual(t, ok, true)
+
+	_, ok = m.Get("d")
+	test.AssertEqual(t, ok, false)
+
+	m.Delete("a")
+	m.Delete("d")
+	test.AssertEqual(t, m.Has("a"), false)
+	test.AssertEqual(t, m.Keys(), []string{"c", "b"})
+
+	// re-adding a deleted key puts it at the end
+	m.Set("a", 5)
+	test.AssertEqual(t, m.Keys(), []string{"c", "b", "a"})
+
+	visited := []string{}
+	m.Each(func(k string, v int) {
+		visited = append(visited, k)
+	})
+	test.AssertEqual(t, visited, []string{"c", "b", "a"})
+}
@@ -0,0 +1,186 @@
+package maze
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/jack-barr3tt/gostuff/cycle"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+// Automaton updates every cell of a grid at once from the cell and its neighbours, like the Game of Life
+type Automaton[T comparable] struct {
+	// Rule returns the next value of a cell. The neighbours slice is reused, so it must not be kept.
+	Rule func(cell T, neighbours []T) T
+	// Neighbourhood is the directions of a cell's neighbours, usually Cardinals or AllDirections
+	Neighbourhood []types.Direction
+	// Wrap makes neighbours past the edge of a maze come from the opposite side.
+	// Otherwise they are left out, so edge cells have fewer neighbours.
+	Wrap bool
+	// Workers is how many goroutines the rows of a maze are split between. Below 2 steps on one goroutine.
+	Workers int
+}
+
+// CountRule adapts a rule that only needs to know how many neighbours have each value
+func CountRule[T comparable](rule func(cell T, counts map[T]int) T) func(cell T, neighbours []T) T {
+	return func(cell T, neighbours []T) T {
+		counts := make(map[T]int, len(neighbours))
+		for _, n := range neighbours {
+			counts[n]++
+		}
+		return rule(cell, counts)
+	}
+}
+
+// Step returns the next generation of a maze, leaving it unchanged
+func (a Automaton[T]) Step(m Maze[T]) Maze[T] {
+	w, h := m.size()
+	next := make(Maze[T], h)
+	for y := range next {
+		next[y] = make([]T, w)
+	}
+	a.StepInto(next, m)
+	return next
+}
+
+// StepInto writes the next generation of src into dst, which must be the same size.
+// Swapping two mazes between calls avoids allocating a new one every step.
+func (a Automaton[T]) StepInto(dst, src Maze[T]) {
+	stepRows := func(from, to int) {
+		neighbours := make([]T, 0, len(a.Neighbourhood))
+		for y := from; y < to; y++ {
+			for x, cell := range src[y] {
+				neighbours = neighbours[:0]
+				for _, d := range a.Neighbourhood {
+					p := types.Point{x + d[0], y + d[1]}
+					if a.Wrap {
+						neighbours = append(neighbours, src.At(src.Wrap(p)))
+					} else if n, ok := src.Move(types.Point{x, y}, d); ok {
+						neighbours = append(neighbours, src.At(n))
+					}
+				}
+				dst[y][x] = a.Rule(cell, neighbours)
+			}
+		}
+	}
+
+	workers := a.Workers
+	if workers > len(src) {
+		workers = len(src)
+	}
+	if workers < 2 {
+		stepRows(0, len(src))
+		return
+	}
+
+	var wg sync.WaitGroup
+	chunk := (len(src) + workers - 1) / workers
+	for from := 0; from < len(src); from += chunk {
+		to := from + chunk
+		if to > len(src) {
+			to = len(src)
+		}
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			stepRows(from, to)
+		}(from, to)
+	}
+	wg.Wait()
+}
+
+// Run steps a maze a number of times and returns the result, leaving the original unchanged
+func (a Automaton[T]) Run(m Maze[T], steps int) Maze[T] {
+	curr, next := m.Clone(), m.Clone()
+	for i := 0; i < steps; i++ {
+		a.StepInto(next, curr)
+		curr, next = next, curr
+	}
+	return curr
+}
+
+// RunUntilStable steps a maze until a step changes nothing.
+// Returns the stable maze and the number of steps that changed it. Never returns if it doesn't settle.
+func (a Automaton[T]) RunUntilStable(m Maze[T]) (Maze[T], int) {
+	curr, next := m.Clone(), m.Clone()
+	for steps := 0; ; steps++ {
+		a.StepInto(next, curr)
+		if equal(curr, next) {
+			return curr, steps
+		}
+		curr, next = next, curr
+	}
+}
+
+// RunUntilCycle steps a maze until it returns to an earlier state, keeping every generation.
+// The result can give the maze after any number of steps with At.
+func (a Automaton[T]) RunUntilCycle(m Maze[T]) cycle.Cycle[Maze[T]] {
+	// every distinct cell value is numbered, and varints can't run into each other,
+	// so two keys only match when the mazes are equal
+	ids := map[T]uint64{}
+	key := func(m Maze[T]) string {
+		w, h := m.size()
+		buf := make([]byte, 0, 2+w*h)
+		buf = binary.AppendUvarint(buf, uint64(w))
+		buf = binary.AppendUvarint(buf, uint64(h))
+		for _, row := range m {
+			for _, cell := range row {
+				id, ok := ids[cell]
+				if !ok {
+					id = uint64(len(ids))
+					ids[cell] = id
+				}
+				buf = binary.AppendUvarint(buf, id)
+			}
+		}
+		return string(buf)
+	}
+
+	return cycle.Detect(m, a.Step, key)
+}
+
+// StepSparse returns the next generation of a sparse grid, leaving it unchanged. Only set cells and
+// their neighbours are updated, so Rule must keep a default cell surrounded by defaults unchanged.
+// Wrap and Workers are ignored.
+func (a Automaton[T]) StepSparse(g *SparseGrid[T]) *SparseGrid[T] {
+	next := NewSparseGrid(g.def)
+
+	// a cell q sees p as its neighbour in direction d when p = q + d, so the cells that see p are at p - d
+	candidates := make(map[types.Point]bool, len(g.cells)*(len(a.Neighbourhood)+1))
+	for p := range g.cells {
+		candidates[p] = true
+		for _, d := range a.Neighbourhood {
+			candidates[p.UnsafeMove(d.Inverse())] = true
+		}
+	}
+
+	neighbours := make([]T, len(a.Neighbourhood))
+	for p := range candidates {
+		for i, d := range a.Neighbourhood {
+			neighbours[i] = g.At(p.UnsafeMove(d))
+		}
+		next.Set(p, a.Rule(g.At(p), neighbours))
+	}
+
+	return next
+}
+
+// RunSparse steps a sparse grid a number of times and returns the result
+func (a Automaton[T]) RunSparse(g *SparseGrid[T], steps int) *SparseGrid[T] {
+	curr := g.Clone()
+	for i := 0; i < steps; i++ {
+		curr = a.StepSparse(curr)
+	}
+	return curr
+}
+
+func equal[T comparable](a, b Maze[T]) bool {
+	for y := range a {
+		for x := range a[y] {
+			if a[y][x] != b[y][x] {
+				return false
+			}
+		}
+	}
+	return true
+}
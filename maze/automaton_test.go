@@ -0,0 +1,102 @@
+package maze
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+var life = Automaton[rune]{
+	Rule: CountRule(func(cell rune, counts map[rune]int) rune {
+		if counts['#'] == 3 || (cell == '#' && counts['#'] == 2) {
+			return '#'
+		}
+		return '.'
+	}),
+	Neighbourhood: AllDirections,
+}
+
+func TestAutomatonStep(t *testing.T) {
+	blinker := NewMaze(".....\n..#..\n..#..\n..#..\n.....")
+
+	next := life.Step(blinker)
+	test.AssertEqual(t, next.Render(runeString), ".....\n.....\n.###.\n.....\n.....\n")
+	test.AssertEqual(t, blinker.Render(runeString), ".....\n..#..\n..#..\n..#..\n.....\n")
+	test.AssertEqual(t, life.Run(blinker, 2).Render(runeString), blinker.Render(runeString))
+
+	// the same glider on a torus comes back to where it started
+	glider := NewMaze(".#....\n..#...\n###...\n......\n......\n......")
+	torus := life
+	torus.Wrap = true
+	test.AssertEqual(t, torus.Run(glider, 24).Render(runeString), glider.Render(runeString))
+	test.AssertNotEqual(t, life.Run(glider, 24).Render(runeString), glider.Render(runeString))
+}
+
+func TestAutomatonWorkers(t *testing.T) {
+	soup := NewMaze("#..##.#.\n.##..#..\n#.#.##.#\n..#...##\n##.#.#..\n.#..##.#\n#..#.#..")
+
+	parallel := life
+	parallel.Workers = 3
+	for steps := 0; steps < 6; steps++ {
+		test.AssertEqual(t, parallel.Run(soup, steps).Render(runeString), life.Run(soup, steps).Render(runeString))
+	}
+}
+
+func TestAutomatonRunUntil(t *testing.T) {
+	block := NewMaze("....\n.##.\n.##.\n....")
+	stable, steps := life.RunUntilStable(block)
+	test.AssertEqual(t, stable.Render(runeString), block.Render(runeString))
+	test.AssertEqual(t, steps, 0)
+
+	// an L of three becomes a block after one step
+	stable, steps = life.RunUntilStable(NewMaze("....\n.#..\n.##.\n...."))
+	test.AssertEqual(t, stable.Render(runeString), block.Render(runeString))
+	test.AssertEqual(t, steps, 1)
+
+	blinker := NewMaze(".....\n..#..\n..#..\n..#..\n.....")
+	c := life.RunUntilCycle(blinker)
+	test.AssertEqual(t, c.Mu, 0)
+	test.AssertEqual(t, c.Lambda, 2)
+	test.AssertEqual(t, c.At(1001).Render(runeString), life.Step(blinker).Render(runeString))
+
+	// mazes that print the same are still different states
+	swap := map[string]string{"a b": "a", "a": "a b", "c": "b c", "b c": "c"}
+	words := Automaton[string]{Rule: func(cell string, neighbours []string) string { return swap[cell] }}
+	wc := words.RunUntilCycle(Maze[string]{{"a b", "c"}})
+	test.AssertEqual(t, wc.Mu, 0)
+	test.AssertEqual(t, wc.Lambda, 2)
+}
+
+func TestAutomatonSparse(t *testing.T) {
+	glider := SparseFromMaze(NewMaze(".#.\n..#\n###"), '.')
+
+	// a glider moves one cell diagonally every four steps
+	moved := life.RunSparse(glider, 4)
+	test.AssertEqual(t, moved.Len(), 5)
+	for _, p := range glider.Points() {
+		test.AssertEqual(t, moved.At(p.UnsafeMove(types.SouthEast)), '#')
+	}
+	test.AssertEqual(t, glider.Len(), 5)
+
+	// counts-only rules see the same neighbours as the dense version
+	dense := NewMaze(".....\n.#.#.\n..##.\n..#..\n.....")
+	sparse := life.StepSparse(SparseFromMaze(dense, '.'))
+	next := life.Step(dense)
+	for y := range next {
+		for x := range next[y] {
+			test.AssertEqual(t, sparse.At(types.Point{x, y}), next[y][x])
+		}
+	}
+
+	// neighbourhoods don't have to be symmetric
+	copyEast := Automaton[rune]{
+		Rule:          func(cell rune, neighbours []rune) rune { return neighbours[0] },
+		Neighbourhood: []types.Direction{types.East},
+	}
+	row := SparseFromMaze(NewMaze("..#."), '.')
+	shifted := copyEast.StepSparse(row)
+	test.AssertEqual(t, shifted.At(types.Point{1, 0}), '#')
+	test.AssertEqual(t, shifted.At(types.Point{2, 0}), '.')
+	test.AssertEqual(t, shifted.Len(), 1)
+}
@@ -0,0 +1,91 @@
+package maze
+
+import "github.com/jack-barr3tt/gostuff/types"
+
+// EdgeMode says what happens when a move would leave a maze
+type EdgeMode int
+
+const (
+	// EdgeBlock refuses the move, like Move does
+	EdgeBlock EdgeMode = iota
+	// EdgeClamp stops at the edge
+	EdgeClamp
+	// EdgeWrap comes back in on the opposite side
+	EdgeWrap
+	// EdgeInfinite allows any point, treating the maze as repeated forever in every direction.
+	// Use AtTiled to read cells outside the base maze.
+	EdgeInfinite
+)
+
+// MoveWith moves from p in direction d, dealing with the edges of the maze as mode says.
+// Returns false if the move was refused or cut short by the edge. An empty maze refuses every move.
+func (m Maze[T]) MoveWith(p types.Point, d types.Direction, mode EdgeMode) (types.Point, bool) {
+	if m.empty() {
+		return p, false
+	}
+	next := p.UnsafeMove(d)
+
+	switch mode {
+	case EdgeClamp:
+		w, h := m.size()
+		clamped := types.Point{clamp(next[0], 0, w-1), clamp(next[1], 0, h-1)}
+		return clamped, clamped == next
+	case EdgeWrap:
+		return m.Wrap(next), true
+	case EdgeInfinite:
+		return next, true
+	}
+	return m.Move(p, d)
+}
+
+// Wrap maps any point into the maze, as if the maze were repeated forever in every direction.
+// An empty maze has nowhere to map to, so p is returned as it is.
+func (m Maze[T]) Wrap(p types.Point) types.Point {
+	if m.empty() {
+		return p
+	}
+	w, h := m.size()
+	return types.Point{mod(p[0], w), mod(p[1], h)}
+}
+
+// AtTiled returns the cell at any point of the maze repeated forever in every direction,
+// along with which copy it is in. The base maze is tile {0, 0}, and the tile to its east is {1, 0}.
+// An empty maze has no cells, so the zero value and tile {0, 0} are returned.
+func (m Maze[T]) AtTiled(p types.Point) (T, types.Point) {
+	if m.empty() {
+		var zero T
+		return zero, types.Point{}
+	}
+	w, h := m.size()
+	tile := types.Point{floorDiv(p[0], w), floorDiv(p[1], h)}
+	return m.At(m.Wrap(p)), tile
+}
+
+func (m Maze[T]) empty() bool {
+	w, h := m.size()
+	return w == 0 || h == 0
+}
+
+func clamp(n, lo, hi int) int {
+	if n < lo {
+		return lo
+	}
+	if n > hi {
+		return hi
+	}
+	return n
+}
+
+// mod is the remainder of a/b that is never negative
+func mod(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+// floorDiv rounds a/b down rather than towards zero
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
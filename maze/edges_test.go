@@ -0,0 +1,75 @@
+package maze
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+func TestMoveWith(t *testing.T) {
+	m := NewBlankMaze(4, 3, '.')
+
+	p, ok := m.MoveWith(types.Point{3, 1}, types.East, EdgeBlock)
+	test.AssertEqual(t, p, types.Point{3, 1})
+	test.AssertEqual(t, ok, false)
+
+	p, ok = m.MoveWith(types.Point{2, 1}, types.East.Multiply(5), EdgeClamp)
+	test.AssertEqual(t, p, types.Point{3, 1})
+	test.AssertEqual(t, ok, false)
+
+	p, ok = m.MoveWith(types.Point{2, 1}, types.East, EdgeClamp)
+	test.AssertEqual(t, p, types.Point{3, 1})
+	test.AssertEqual(t, ok, true)
+
+	p, ok = m.MoveWith(types.Point{3, 0}, types.SouthEast, EdgeWrap)
+	test.AssertEqual(t, p, types.Point{0, 2})
+	test.AssertEqual(t, ok, true)
+
+	// robots can teleport many widths at once
+	p, _ = m.MoveWith(types.Point{1, 1}, types.Direction{-9, 7}, EdgeWrap)
+	test.AssertEqual(t, p, types.Point{0, 2})
+
+	p, ok = m.MoveWith(types.Point{0, 0}, types.SouthWest, EdgeInfinite)
+	test.AssertEqual(t, p, types.Point{-1, -1})
+	test.AssertEqual(t, ok, true)
+}
+
+func TestAtTiled(t *testing.T) {
+	m := NewMazeOriented(`S.
+.#`, types.YDown)
+
+	cell, tile := m.AtTiled(types.Point{1, 1})
+	test.AssertEqual(t, cell, '#')
+	test.AssertEqual(t, tile, types.Point{0, 0})
+
+	cell, tile = m.AtTiled(types.Point{5, 3})
+	test.AssertEqual(t, cell, '#')
+	test.AssertEqual(t, tile, types.Point{2, 1})
+
+	cell, tile = m.AtTiled(types.Point{-2, -1})
+	test.AssertEqual(t, cell, '.')
+	test.AssertEqual(t, tile, types.Point{-1, -1})
+
+	cell, tile = m.AtTiled(types.Point{-1, -1})
+	test.AssertEqual(t, cell, '#')
+	test.AssertEqual(t, tile, types.Point{-1, -1})
+
+	test.AssertEqual(t, m.Wrap(types.Point{-4, 6}), types.Point{0, 0})
+}
+
+func TestEdgesEmptyMaze(t *testing.T) {
+	for _, m := range []Maze[rune]{{}, {{}}} {
+		for _, mode := range []EdgeMode{EdgeBlock, EdgeClamp, EdgeWrap, EdgeInfinite} {
+			p, ok := m.MoveWith(types.Point{0, 0}, types.East, mode)
+			test.AssertEqual(t, p, types.Point{0, 0})
+			test.AssertEqual(t, ok, false)
+		}
+
+		test.AssertEqual(t, m.Wrap(types.Point{3, -2}), types.Point{3, -2})
+
+		cell, tile := m.AtTiled(types.Point{3, -2})
+		test.AssertEqual(t, cell, rune(0))
+		test.AssertEqual(t, tile, types.Point{0, 0})
+	}
+}
@@ -2,7 +2,10 @@ package maze
 
 import (
 	"strings"
+	"unicode/utf8"
 
+	"github.com/jack-barr3tt/gostuff/chars"
+	"github.com/jack-barr3tt/gostuff/nums"
 	"github.com/jack-barr3tt/gostuff/set"
 	"github.com/jack-barr3tt/gostuff/slices"
 	"github.com/jack-barr3tt/gostuff/types"
@@ -13,15 +16,93 @@ type Maze[T comparable] [][]T
 type RuneMaze Maze[rune]
 
 func NewMaze(raw string) Maze[rune] {
-	lines := strings.Split(raw, "\n")
+	return NewMazeOriented(raw, types.YUp).Maze
+}
+
+// NewMazeOriented parses a maze with y growing upwards for YUp, like NewMaze, or downwards for YDown
+// so that points line up with the rows and columns of the text
+func NewMazeOriented(raw string, o types.Orientation) OrientedMaze[rune] {
+	return NewMazeFuncOriented(raw, func(r rune) rune { return r }, o)
+}
 
-	maze := make([][]rune, len(lines))
+// NewMazeFunc parses a maze like NewMaze, converting each character with fn
+func NewMazeFunc[T comparable](raw string, fn func(r rune) T) Maze[T] {
+	return NewMazeFuncOriented(raw, fn, types.YUp).Maze
+}
 
+// NewMazeFuncOriented parses a maze in the given orientation, converting each character with fn.
+// Trailing newlines are ignored, and lines shorter than the longest are padded with fn(' ').
+func NewMazeFuncOriented[T comparable](raw string, fn func(r rune) T, o types.Orientation) OrientedMaze[T] {
+	lines := strings.Split(strings.TrimRight(raw, "\r\n"), "\n")
+
+	width := 0
 	for i, line := range lines {
-		maze[len(lines)-1-i] = []rune(line)
+		lines[i] = strings.TrimSuffix(line, "\r")
+		width = nums.Max(width, utf8.RuneCountInString(lines[i]))
 	}
 
-	return maze
+	maze := make([][]T, len(lines))
+
+	for i, line := range lines {
+		row := make([]T, 0, width)
+		for _, r := range line {
+			row = append(row, fn(r))
+		}
+		for len(row) < width {
+			row = append(row, fn(' '))
+		}
+
+		if o == types.YDown {
+			maze[i] = row
+		} else {
+			maze[len(lines)-1-i] = row
+		}
+	}
+
+	return Maze[T](maze).Oriented(o)
+}
+
+// NewMazes parses several mazes separated by empty lines, each like NewMaze
+func NewMazes(raw string) []Maze[rune] {
+	return NewMazesFunc(raw, func(r rune) rune { return r })
+}
+
+// NewMazesFunc parses several mazes separated by empty lines, each like NewMazeFunc
+func NewMazesFunc[T comparable](raw string, fn func(r rune) T) []Maze[T] {
+	oriented := NewMazesFuncOriented(raw, fn, types.YUp)
+	mazes := make([]Maze[T], len(oriented))
+	for i, m := range oriented {
+		mazes[i] = m.Maze
+	}
+	return mazes
+}
+
+// NewMazesFuncOriented parses several mazes separated by empty lines, each like NewMazeFuncOriented.
+// Lines of only whitespace are padding within a maze rather than separators.
+func NewMazesFuncOriented[T comparable](raw string, fn func(r rune) T, o types.Orientation) []OrientedMaze[T] {
+	mazes := []OrientedMaze[T]{}
+	block := []string{}
+
+	for _, line := range strings.Split(raw+"\n", "\n") {
+		if strings.TrimSuffix(line, "\r") != "" {
+			block = append(block, line)
+			continue
+		}
+		if len(block) > 0 {
+			mazes = append(mazes, NewMazeFuncOriented(strings.Join(block, "\n"), fn, o))
+			block = []string{}
+		}
+	}
+
+	return mazes
+}
+
+// Digit converts a digit to its value, and anything else to -1, for use with NewMazeFunc
+func Digit(r rune) int {
+	if !chars.CharIsDigit(r) {
+		return -1
+	}
+	return int(r - '0')
 }
 
 func NewBlankMaze[T comparable](width, height int, empty T) Maze[T] {
@@ -76,39 +157,77 @@ func (m Maze[T]) Clone() Maze[T] {
 	return clone
 }
 
-func (m RuneMaze) Print() {
-	output := ""
-	for i := range m {
-		for j := range m[len(m)-1-i] {
-			output += string(m[len(m)-1-i][j])
-		}
-		output += "\n"
-	}
-	println(output)
-}
-
+// Rotate turns the maze by a multiple of 90 degrees, anticlockwise when y is up.
+// Rectangular mazes swap their width and height for quarter turns.
 func (m Maze[T]) Rotate(deg int) Maze[T] {
 	normalised := ((deg % 360) + 360) % 360
 	if normalised%90 != 0 {
 		panic("Can only rotate in 90 degree increments")
 	}
-	if len(m) != len(m[0]) {
-		panic("Can only rotate square mazes")
+
+	w, h := m.size()
+	switch normalised {
+	case 90:
+		return m.remap(h, w, func(x, y int) types.Point { return types.Point{y, h - 1 - x} })
+	case 180:
+		return m.remap(w, h, func(x, y int) types.Point { return types.Point{w - 1 - x, h - 1 - y} })
+	case 270:
+		return m.remap(h, w, func(x, y int) types.Point { return types.Point{w - 1 - y, x} })
 	}
+	return m.Clone()
+}
 
-	rotated := m.Clone()
+// Transpose swaps the x and y of every cell
+func (m Maze[T]) Transpose() Maze[T] {
+	w, h := m.size()
+	return m.remap(h, w, func(x, y int) types.Point { return types.Point{y, x} })
+}
 
-	for i := 0; i < normalised/90; i++ {
-		temp := rotated.Clone()
-		for y := range rotated {
-			for x := range rotated[y] {
-				temp[x][len(rotated)-1-y] = rotated[y][x]
-			}
-		}
-		rotated = temp
+// FlipHorizontal mirrors the maze left to right
+func (m Maze[T]) FlipHorizontal() Maze[T] {
+	w, h := m.size()
+	return m.remap(w, h, func(x, y int) types.Point { return types.Point{w - 1 - x, y} })
+}
+
+// FlipVertical mirrors the maze top to bottom
+func (m Maze[T]) FlipVertical() Maze[T] {
+	w, h := m.size()
+	return m.remap(w, h, func(x, y int) types.Point { return types.Point{x, h - 1 - y} })
+}
+
+// Symmetries returns all 8 ways the maze can be turned and flipped: turned 0, 90, 180 and 270 degrees
+// as by Rotate, then the same four turns of FlipHorizontal. Useful for matching tiles in any orientation.
+func (m Maze[T]) Symmetries() []Maze[T] {
+	w, h := m.size()
+	return []Maze[T]{
+		m.Clone(),
+		m.Rotate(90),
+		m.Rotate(180),
+		m.Rotate(270),
+		m.FlipHorizontal(),
+		m.remap(h, w, func(x, y int) types.Point { return types.Point{w - 1 - y, h - 1 - x} }),
+		m.FlipVertical(),
+		m.Transpose(),
+	}
+}
+
+func (m Maze[T]) size() (width, height int) {
+	if len(m) == 0 {
+		return 0, 0
 	}
+	return len(m[0]), len(m)
+}
 
-	return rotated
+// remap builds a maze of the given size in a single pass, taking each cell from the point in m that from gives
+func (m Maze[T]) remap(width, height int, from func(x, y int) types.Point) Maze[T] {
+	out := make([][]T, height)
+	for y := range out {
+		out[y] = make([]T, width)
+		for x := range out[y] {
+			out[y][x] = m.At(from(x, y))
+		}
+	}
+	return out
 }
 
 func (m Maze[T]) SubMazeAt(m2 Maze[T], origin types.Point, ignore []T) bool {
@@ -129,8 +248,6 @@ func (m Maze[T]) SubMazeAt(m2 Maze[T], origin types.Point, ignore []T) bool {
 	return true
 }
 
-var nesw = []types.Direction{types.North, types.East, types.South, types.West}
-
 func (m Maze[T]) FloodFill(start types.Point, empty, fill T) {
 	queue := []types.Point{start}
 
@@ -144,7 +261,7 @@ func (m Maze[T]) FloodFill(start types.Point, empty, fill T) {
 
 		m.Set(current, fill)
 
-		for _, d := range nesw {
+		for _, d := range Cardinals {
 			neighbor, ok := m.Move(current, d)
 			if ok && m.At(neighbor) == empty {
 				queue = append(queue, neighbor)
@@ -29,6 +29,69 @@ L######`
 	test.AssertEqual(t, NewMaze(raw), expected)
 }
 
+func TestNewMazeTrailingNewline(t *testing.T) {
+	test.AssertEqual(t, NewMaze("ab\ncd\n"), NewMaze("ab\ncd"))
+	test.AssertEqual(t, NewMaze("ab\r\ncd\r\n"), NewMaze("ab\ncd"))
+	test.AssertEqual(t, NewMaze("ab\ncd\n").Height(), 2)
+}
+
+func TestNewMazeRagged(t *testing.T) {
+	maze := NewMaze(`#
+###
+##`)
+
+	expected := Maze[rune]{
+		{'#', '#', ' '},
+		{'#', '#', '#'},
+		{'#', ' ', ' '},
+	}
+
+	test.AssertEqual(t, maze, expected)
+}
+
+func TestNewMazeFunc(t *testing.T) {
+	heights := NewMazeFunc(`0123
+45.7`, Digit)
+
+	expected := Maze[int]{
+		{4, 5, -1, 7},
+		{0, 1, 2, 3},
+	}
+
+	test.AssertEqual(t, heights, expected)
+
+	walls := NewMazeFunc("#.\n.#\n", func(r rune) bool { return r == '#' })
+	test.AssertEqual(t, walls, Maze[bool]{{false, true}, {true, false}})
+}
+
+func TestNewMazes(t *testing.T) {
+	// From Advent of Code 2023 Day 13 example
+	mazes := NewMazes(`#.##..##.
+..#.##.#.
+
+#...##..#
+#....#..#
+
+`)
+
+	test.AssertEqual(t, len(mazes), 2)
+	test.AssertEqual(t, mazes[0], NewMaze("#.##..##.\n..#.##.#."))
+	test.AssertEqual(t, mazes[1].Height(), 2)
+
+	// several blank lines in a row still only separate two mazes
+	locks := NewMazesFunc("#\n.\n\n\n.\n#\n", func(r rune) bool { return r == '#' })
+	test.AssertEqual(t, locks, []Maze[bool]{{{false}, {true}}, {{true}, {false}}})
+
+	// a line of only spaces is part of the maze, not a separator
+	padded := NewMazes("a b\n   \nc d\n\r\ne\n")
+	test.AssertEqual(t, len(padded), 2)
+	test.AssertEqual(t, padded[0], NewMaze("a b\n   \nc d"))
+
+	oriented := NewMazesFuncOriented("ab\ncd\n\nef\n", func(r rune) rune { return r }, types.YDown)
+	test.AssertEqual(t, oriented, []OrientedMaze[rune]{NewMazeOriented("ab\ncd", types.YDown), NewMazeOriented("ef", types.YDown)})
+	test.AssertEqual(t, oriented[0].At(types.Point{0, 0}), 'a')
+}
+
 func TestNewBlankMaze(t *testing.T) {
 	g1 := NewBlankMaze(3, 3, '.')
 
@@ -170,6 +233,65 @@ func TestMazeRotate(t *testing.T) {
 	test.AssertEqual(t, rotated, expected180)
 }
 
+func TestMazeRotateRectangle(t *testing.T) {
+	maze := NewMaze(`ab
+cd
+ef`)
+
+	test.AssertEqual(t, maze.Rotate(90), NewMaze(`bdf
+ace`))
+	test.AssertEqual(t, maze.Rotate(180), NewMaze(`fe
+dc
+ba`))
+	test.AssertEqual(t, maze.Rotate(270), NewMaze(`eca
+fdb`))
+	test.AssertEqual(t, maze.Rotate(-90), maze.Rotate(270))
+	test.AssertEqual(t, maze.Rotate(90).Rotate(270), maze)
+}
+
+func TestTranspose(t *testing.T) {
+	maze := NewMazeOriented(`abc
+def`, types.YDown).Maze
+
+	test.AssertEqual(t, maze.Transpose(), NewMazeOriented(`ad
+be
+cf`, types.YDown).Maze)
+	test.AssertEqual(t, maze.Transpose().Transpose(), maze)
+}
+
+func TestFlip(t *testing.T) {
+	maze := NewMaze(`abc
+def`)
+
+	test.AssertEqual(t, maze.FlipHorizontal(), NewMaze(`cba
+fed`))
+	test.AssertEqual(t, maze.FlipVertical(), NewMaze(`def
+abc`))
+	test.AssertEqual(t, maze.FlipHorizontal().FlipVertical(), maze.Rotate(180))
+}
+
+func TestSymmetries(t *testing.T) {
+	maze := NewMaze(`ab
+cd
+ef`)
+
+	symmetries := maze.Symmetries()
+	test.AssertEqual(t, len(symmetries), 8)
+
+	// each one is a different arrangement
+	seen := map[string]bool{}
+	for _, s := range symmetries {
+		seen[s.Render(func(r rune) string { return string(r) })] = true
+	}
+	test.AssertEqual(t, len(seen), 8)
+
+	flipped := maze.FlipHorizontal()
+	for i, turn := range []int{0, 90, 180, 270} {
+		test.AssertEqual(t, symmetries[i], maze.Rotate(turn))
+		test.AssertEqual(t, symmetries[4+i], flipped.Rotate(turn))
+	}
+}
+
 func TestSubMazeAt(t *testing.T) {
 	maze := NewMaze(`MMMSXXMASM
 MSAMXMSMSA
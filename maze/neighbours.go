@@ -0,0 +1,29 @@
+package maze
+
+import "github.com/jack-barr3tt/gostuff/types"
+
+var (
+	Cardinals     = []types.Direction{types.North, types.East, types.South, types.West}
+	Diagonals     = []types.Direction{types.NorthEast, types.SouthEast, types.SouthWest, types.NorthWest}
+	AllDirections = []types.Direction{types.North, types.NorthEast, types.East, types.SouthEast, types.South, types.SouthWest, types.West, types.NorthWest}
+)
+
+// Neighbours returns the points one step from p in each of dirs that are inside the maze
+func (m Maze[T]) Neighbours(p types.Point, dirs []types.Direction) []types.Point {
+	points := make([]types.Point, 0, len(dirs))
+	for _, d := range dirs {
+		if n, ok := m.Move(p, d); ok {
+			points = append(points, n)
+		}
+	}
+	return points
+}
+
+// Neighbours returns the points one step from p in each of dirs
+func (g *SparseGrid[T]) Neighbours(p types.Point, dirs []types.Direction) []types.Point {
+	points := make([]types.Point, len(dirs))
+	for i, d := range dirs {
+		points[i] = p.UnsafeMove(d)
+	}
+	return points
+}
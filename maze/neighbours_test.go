@@ -0,0 +1,27 @@
+package maze
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+func TestMazeNeighbours(t *testing.T) {
+	m := NewBlankMaze(3, 3, '.')
+
+	test.AssertSlicesEqual(t, m.Neighbours(types.Point{1, 1}, Cardinals), []types.Point{{1, 2}, {2, 1}, {1, 0}, {0, 1}})
+	test.AssertEqual(t, len(m.Neighbours(types.Point{1, 1}, AllDirections)), 8)
+
+	// only points inside the maze count
+	test.AssertSlicesEqual(t, m.Neighbours(types.Point{0, 0}, AllDirections), []types.Point{{0, 1}, {1, 1}, {1, 0}})
+	test.AssertSlicesEqual(t, m.Neighbours(types.Point{0, 0}, Diagonals), []types.Point{{1, 1}})
+}
+
+func TestSparseGridNeighbours(t *testing.T) {
+	g := NewSparseGrid(0)
+
+	// a sparse grid has no edges
+	test.AssertSlicesEqual(t, g.Neighbours(types.Point{0, 0}, Diagonals), []types.Point{{1, 1}, {1, -1}, {-1, -1}, {-1, 1}})
+	test.AssertEqual(t, len(g.Neighbours(types.Point{0, 0}, AllDirections)), 8)
+}
@@ -0,0 +1,79 @@
+package maze
+
+import (
+	"io"
+	"os"
+
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+// OrientedMaze is a maze that remembers which way y grows, so anything that depends on it
+// (text coordinates, rotating, printing) doesn't have to be told again
+type OrientedMaze[T comparable] struct {
+	Maze[T]
+	Orientation types.Orientation
+}
+
+// Oriented wraps a maze laid out in the given orientation. Mazes from NewMaze and NewMazeFunc are YUp.
+func (m Maze[T]) Oriented(o types.Orientation) OrientedMaze[T] {
+	return OrientedMaze[T]{Maze: m, Orientation: o}
+}
+
+// Reorient returns a copy of the maze laid out in another orientation,
+// so the same cell can be reached with the other orientation's directions
+func (m OrientedMaze[T]) Reorient(to types.Orientation) OrientedMaze[T] {
+	if m.Orientation == to {
+		return m.Maze.Clone().Oriented(to)
+	}
+
+	return m.Maze.FlipVertical().Oriented(to)
+}
+
+// FromText returns the point of the cell at a row and column of the text the maze was parsed from.
+// Rows and columns count from 0 at the top left.
+func (m OrientedMaze[T]) FromText(row, col int) types.Point {
+	if m.Orientation == types.YDown {
+		return types.Point{col, row}
+	}
+	return types.Point{col, len(m.Maze) - 1 - row}
+}
+
+// ToText returns the row and column of a point in the text the maze was parsed from
+func (m OrientedMaze[T]) ToText(p types.Point) (row, col int) {
+	if m.Orientation == types.YDown {
+		return p[1], p[0]
+	}
+	return len(m.Maze) - 1 - p[1], p[0]
+}
+
+// RotateClockwise turns the maze clockwise as it is displayed by a multiple of 90 degrees,
+// the same way Orientation.Rotate turns directions. The embedded Maze.Rotate is left as it is,
+// and turns by the stored coordinates, which is anticlockwise on screen for a YUp maze.
+func (m OrientedMaze[T]) RotateClockwise(deg int) OrientedMaze[T] {
+	if m.Orientation == types.YDown {
+		return m.Maze.Rotate(deg).Oriented(m.Orientation)
+	}
+	return m.Maze.Rotate(-deg).Oriented(m.Orientation)
+}
+
+// Render draws the maze as text with north at the top for its orientation
+func (m OrientedMaze[T]) Render(format func(cell T) string) string {
+	return m.RenderWith(format, RenderOptions{})
+}
+
+// RenderWith is Maze.RenderWith using the maze's orientation in place of opts.Orientation
+func (m OrientedMaze[T]) RenderWith(format func(cell T) string, opts RenderOptions) string {
+	opts.Orientation = m.Orientation
+	return m.Maze.RenderWith(format, opts)
+}
+
+// RenderTo is Maze.RenderTo using the maze's orientation in place of opts.Orientation
+func (m OrientedMaze[T]) RenderTo(w io.Writer, format func(cell T) string, opts RenderOptions) error {
+	opts.Orientation = m.Orientation
+	return m.Maze.RenderTo(w, format, opts)
+}
+
+// Print prints the maze to stdout with north at the top for its orientation
+func (m OrientedMaze[T]) Print(format func(cell T) string) {
+	m.RenderTo(os.Stdout, format, RenderOptions{})
+}
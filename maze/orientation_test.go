@@ -0,0 +1,85 @@
+package maze
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+const lShape = `#..
+#..
+##.`
+
+func TestNewMazeOriented(t *testing.T) {
+	up := NewMazeOriented(lShape, types.YUp)
+	down := NewMazeOriented(lShape, types.YDown)
+
+	test.AssertEqual(t, up.Maze, NewMaze(lShape))
+	test.AssertEqual(t, up.Orientation, types.YUp)
+	test.AssertEqual(t, down.Orientation, types.YDown)
+	test.AssertEqual(t, down.Maze[0], []rune("#.."))
+	test.AssertEqual(t, down.Reorient(types.YUp), up)
+	test.AssertEqual(t, up.Reorient(types.YDown), down)
+	test.AssertEqual(t, up.Reorient(types.YUp), up)
+	test.AssertEqual(t, NewMaze(lShape).Oriented(types.YUp), up)
+
+	// the corner of the L is at the bottom left either way
+	test.AssertEqual(t, up.At(types.Point{0, 0}), '#')
+	test.AssertEqual(t, down.At(types.Point{0, 2}), '#')
+
+	// and north moves up the page in both
+	p, _ := up.Move(types.Point{1, 0}, up.Orientation.North())
+	test.AssertEqual(t, up.At(p), '.')
+	p, _ = down.Move(types.Point{1, 2}, down.Orientation.North())
+	test.AssertEqual(t, down.At(p), '.')
+
+	// both print the same as the text they were parsed from
+	runeString := func(r rune) string { return string(r) }
+	test.AssertEqual(t, up.Render(runeString), lShape+"\n")
+	test.AssertEqual(t, down.Render(runeString), lShape+"\n")
+}
+
+func TestTextCoordinates(t *testing.T) {
+	for _, o := range []types.Orientation{types.YUp, types.YDown} {
+		m := NewMazeOriented(lShape, o)
+
+		// the second # of the bottom row of the text
+		p := m.FromText(2, 1)
+		test.AssertEqual(t, m.At(p), '#')
+
+		row, col := m.ToText(p)
+		test.AssertEqual(t, row, 2)
+		test.AssertEqual(t, col, 1)
+	}
+
+	test.AssertEqual(t, NewMazeOriented(lShape, types.YUp).FromText(0, 0), types.Point{0, 2})
+	test.AssertEqual(t, NewMazeOriented(lShape, types.YDown).FromText(0, 0), types.Point{0, 0})
+}
+
+func TestRotateClockwise(t *testing.T) {
+	// turning clockwise looks the same whichever way the maze is stored
+	expected := `###
+#..
+...`
+
+	for _, o := range []types.Orientation{types.YUp, types.YDown} {
+		rotated := NewMazeOriented(lShape, o).RotateClockwise(90)
+		test.AssertEqual(t, rotated, NewMazeOriented(expected, o))
+	}
+
+	// the embedded Rotate still agrees with Maze.Rotate
+	test.AssertEqual(t, NewMazeOriented(lShape, types.YUp).Rotate(90), NewMaze(lShape).Rotate(90))
+
+	// a cell that was north of the corner is now east of it, as with Orientation.Rotate
+	for _, o := range []types.Orientation{types.YUp, types.YDown} {
+		m := NewMazeOriented(`#.
+##`, o).RotateClockwise(90)
+		corner := m.FromText(0, 0)
+		test.AssertEqual(t, m.At(corner), '#')
+		p, _ := m.Move(corner, o.Rotate(o.North(), 90))
+		test.AssertEqual(t, m.At(p), '#')
+		p, _ = m.Move(corner, o.South())
+		test.AssertEqual(t, m.At(p), '#')
+	}
+}
@@ -0,0 +1,129 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+// Overlay draws Mark in place of the cells at Points, e.g. to show a path through the maze
+type Overlay struct {
+	Points []types.Point
+	Mark   string
+}
+
+// RenderOptions control how a maze is drawn. Later overlays are drawn over earlier ones.
+type RenderOptions struct {
+	Orientation types.Orientation
+	Overlays    []Overlay
+}
+
+// Render draws the maze as text with north at the top, using format to draw each cell
+func (m Maze[T]) Render(format func(cell T) string) string {
+	return m.RenderWith(format, RenderOptions{})
+}
+
+// RenderWith draws the maze as text like Render, with the given orientation and overlays
+func (m Maze[T]) RenderWith(format func(cell T) string, opts RenderOptions) string {
+	var sb strings.Builder
+	m.RenderTo(&sb, format, opts)
+	return sb.String()
+}
+
+// RenderTo writes the maze as text to w like RenderWith
+func (m Maze[T]) RenderTo(w io.Writer, format func(cell T) string, opts RenderOptions) error {
+	marks := map[types.Point]string{}
+	for _, overlay := range opts.Overlays {
+		for _, p := range overlay.Points {
+			marks[p] = overlay.Mark
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := range m {
+		y := len(m) - 1 - i
+		if opts.Orientation == types.YDown {
+			y = i
+		}
+
+		for x, cell := range m[y] {
+			if mark, ok := marks[types.Point{x, y}]; ok {
+				bw.WriteString(mark)
+			} else {
+				bw.WriteString(format(cell))
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func (m RuneMaze) Print() {
+	Maze[rune](m).RenderTo(os.Stdout, func(r rune) string { return string(r) }, RenderOptions{})
+}
+
+// ImageOptions control how a maze is drawn as an image. Each cell is Scale pixels square, at least 1.
+type ImageOptions struct {
+	Orientation types.Orientation
+	Scale       int
+}
+
+// ToImage draws the maze with north at the top, colouring each cell with colour
+func (m Maze[T]) ToImage(colour func(cell T) color.Color, opts ImageOptions) image.Image {
+	scale := opts.Scale
+	if scale < 1 {
+		scale = 1
+	}
+
+	width := 0
+	if len(m) > 0 {
+		width = len(m[0])
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width*scale, len(m)*scale))
+
+	for i := range m {
+		y := len(m) - 1 - i
+		if opts.Orientation == types.YDown {
+			y = i
+		}
+
+		for x, cell := range m[y] {
+			c := colour(cell)
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.Set(x*scale+dx, i*scale+dy, c)
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// WritePNG writes the maze as a PNG image to w
+func (m Maze[T]) WritePNG(w io.Writer, colour func(cell T) color.Color, opts ImageOptions) error {
+	return png.Encode(w, m.ToImage(colour, opts))
+}
+
+// WritePPM writes the maze as a binary PPM image to w, a format simple enough to write without any encoder
+func (m Maze[T]) WritePPM(w io.Writer, colour func(cell T) color.Color, opts ImageOptions) error {
+	img := m.ToImage(colour, opts)
+	bounds := img.Bounds()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P6\n%d %d\n255\n", bounds.Dx(), bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			bw.Write([]byte{byte(r >> 8), byte(g >> 8), byte(b >> 8)})
+		}
+	}
+	return bw.Flush()
+}
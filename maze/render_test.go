@@ -0,0 +1,91 @@
+package maze
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+func runeString(r rune) string {
+	return string(r)
+}
+
+func TestRender(t *testing.T) {
+	raw := "#..\n#.#\n###\n"
+
+	test.AssertEqual(t, NewMaze(raw).Render(runeString), raw)
+	test.AssertEqual(t, NewMazeOriented(raw, types.YDown).Render(runeString), raw)
+	test.AssertEqual(t, NewMaze(raw).RenderWith(runeString, RenderOptions{Orientation: types.YDown}), "###\n#.#\n#..\n")
+
+	heights := NewMazeFunc("19\n28", Digit)
+	test.AssertEqual(t, heights.Render(func(h int) string {
+		if h > 5 {
+			return "^"
+		}
+		return "_"
+	}), "_^\n_^\n")
+}
+
+func TestRenderOverlays(t *testing.T) {
+	m := NewMaze(`....
+....`)
+
+	rendered := m.RenderWith(runeString, RenderOptions{Overlays: []Overlay{
+		{Points: []types.Point{{0, 0}, {1, 0}, {2, 0}, {2, 1}}, Mark: "O"},
+		{Points: []types.Point{{0, 0}}, Mark: "S"},
+	}})
+
+	test.AssertEqual(t, rendered, "..O.\nSOO.\n")
+}
+
+func TestRenderTo(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewMaze("ab\ncd").RenderTo(&buf, runeString, RenderOptions{})
+
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, buf.String(), "ab\ncd\n")
+}
+
+func wallColour(r rune) color.Color {
+	if r == '#' {
+		return color.Black
+	}
+	return color.White
+}
+
+func TestToImage(t *testing.T) {
+	img := NewMaze("#.\n..").ToImage(wallColour, ImageOptions{Scale: 3})
+
+	test.AssertEqual(t, img.Bounds().Dx(), 6)
+	test.AssertEqual(t, img.Bounds().Dy(), 6)
+
+	// the wall is drawn at the top left
+	r, _, _, _ := img.At(2, 2).RGBA()
+	test.AssertEqual(t, r, uint32(0))
+	r, _, _, _ = img.At(3, 2).RGBA()
+	test.AssertEqual(t, r, uint32(0xffff))
+}
+
+func TestWritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewMaze("#.\n..").WritePNG(&buf, wallColour, ImageOptions{})
+	test.AssertEqual(t, err, nil)
+
+	img, err := png.Decode(&buf)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, img.Bounds().Dx(), 2)
+}
+
+func TestWritePPM(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewMaze("#.").WritePPM(&buf, wallColour, ImageOptions{})
+	test.AssertEqual(t, err, nil)
+
+	test.AssertEqual(t, strings.HasPrefix(buf.String(), "P6\n2 1\n255\n"), true)
+	test.AssertEqual(t, buf.Bytes()[len(buf.Bytes())-6:], []byte{0, 0, 0, 255, 255, 255})
+}
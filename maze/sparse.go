@@ -0,0 +1,173 @@
+package maze
+
+import (
+	"sort"
+
+	"github.com/jack-barr3tt/gostuff/nums"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+// SparseGrid is an unbounded grid that only stores the cells that don't hold the default value
+type SparseGrid[T comparable] struct {
+	cells    map[types.Point]T
+	def      T
+	min, max types.Point
+	// stale is set when a cell on the edge of the bounds is removed
+	stale bool
+}
+
+func NewSparseGrid[T comparable](def T) *SparseGrid[T] {
+	return &SparseGrid[T]{cells: make(map[types.Point]T), def: def}
+}
+
+// SparseFromMaze copies every cell of a maze that isn't def into a new sparse grid
+func SparseFromMaze[T comparable](m Maze[T], def T) *SparseGrid[T] {
+	g := NewSparseGrid(def)
+	for y, row := range m {
+		for x, cell := range row {
+			g.Set(types.Point{x, y}, cell)
+		}
+	}
+	return g
+}
+
+// Default returns the value of every cell that hasn't been set
+func (g *SparseGrid[T]) Default() T {
+	return g.def
+}
+
+func (g *SparseGrid[T]) At(p types.Point) T {
+	if v, ok := g.cells[p]; ok {
+		return v
+	}
+	return g.def
+}
+
+// Has returns whether a cell holds something other than the default
+func (g *SparseGrid[T]) Has(p types.Point) bool {
+	_, ok := g.cells[p]
+	return ok
+}
+
+// Set stores a value in a cell. Setting the default value deletes the cell.
+func (g *SparseGrid[T]) Set(p types.Point, v T) {
+	if v == g.def {
+		g.Delete(p)
+		return
+	}
+
+	if len(g.cells) == 0 {
+		g.min, g.max, g.stale = p, p, false
+	} else if !g.stale {
+		g.min = types.Point{nums.Min(g.min[0], p[0]), nums.Min(g.min[1], p[1])}
+		g.max = types.Point{nums.Max(g.max[0], p[0]), nums.Max(g.max[1], p[1])}
+	}
+	g.cells[p] = v
+}
+
+// Delete resets a cell to the default value
+func (g *SparseGrid[T]) Delete(p types.Point) {
+	if _, ok := g.cells[p]; !ok {
+		return
+	}
+	delete(g.cells, p)
+	if p[0] == g.min[0] || p[1] == g.min[1] || p[0] == g.max[0] || p[1] == g.max[1] {
+		g.stale = true
+	}
+}
+
+// Len returns the number of cells that hold something other than the default
+func (g *SparseGrid[T]) Len() int {
+	return len(g.cells)
+}
+
+// Bounds returns the smallest and largest corners of the box containing every set cell.
+// Returns false if no cells are set.
+func (g *SparseGrid[T]) Bounds() (min, max types.Point, ok bool) {
+	if len(g.cells) == 0 {
+		return types.Point{}, types.Point{}, false
+	}
+
+	if g.stale {
+		first := true
+		for p := range g.cells {
+			if first {
+				g.min, g.max = p, p
+				first = false
+				continue
+			}
+			g.min = types.Point{nums.Min(g.min[0], p[0]), nums.Min(g.min[1], p[1])}
+			g.max = types.Point{nums.Max(g.max[0], p[0]), nums.Max(g.max[1], p[1])}
+		}
+		g.stale = false
+	}
+
+	return g.min, g.max, true
+}
+
+// Points returns every set cell ordered by y and then x
+func (g *SparseGrid[T]) Points() []types.Point {
+	points := make([]types.Point, 0, len(g.cells))
+	for p := range g.cells {
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i][1] != points[j][1] {
+			return points[i][1] < points[j][1]
+		}
+		return points[i][0] < points[j][0]
+	})
+	return points
+}
+
+// Each calls fn with every set cell, in the same order as Points
+func (g *SparseGrid[T]) Each(fn func(p types.Point, v T)) {
+	for _, p := range g.Points() {
+		fn(p, g.cells[p])
+	}
+}
+
+func (g *SparseGrid[T]) Clone() *SparseGrid[T] {
+	clone := &SparseGrid[T]{cells: make(map[types.Point]T, len(g.cells)), def: g.def, min: g.min, max: g.max, stale: g.stale}
+	for p, v := range g.cells {
+		clone.cells[p] = v
+	}
+	return clone
+}
+
+// ToMaze copies the cells inside the bounds into a maze. The returned offset is the grid point
+// at the maze's origin, so a maze point plus the offset gives the grid point.
+func (g *SparseGrid[T]) ToMaze() (Maze[T], types.Point) {
+	min, max, ok := g.Bounds()
+	if !ok {
+		return Maze[T]{}, types.Point{}
+	}
+
+	m := NewBlankMaze(max[0]-min[0]+1, max[1]-min[1]+1, g.def)
+	for p, v := range g.cells {
+		m.Set(types.Point{p[0] - min[0], p[1] - min[1]}, v)
+	}
+	return m, min
+}
+
+// Render draws the cells inside the bounds as text like Maze.Render
+func (g *SparseGrid[T]) Render(format func(cell T) string) string {
+	return g.RenderWith(format, RenderOptions{})
+}
+
+// RenderWith draws the cells inside the bounds as text like Maze.RenderWith.
+// Overlay points are grid points, and any outside the bounds are not drawn.
+func (g *SparseGrid[T]) RenderWith(format func(cell T) string, opts RenderOptions) string {
+	m, offset := g.ToMaze()
+
+	shifted := make([]Overlay, len(opts.Overlays))
+	for i, overlay := range opts.Overlays {
+		shifted[i] = Overlay{Mark: overlay.Mark, Points: make([]types.Point, len(overlay.Points))}
+		for j, p := range overlay.Points {
+			shifted[i].Points[j] = types.Point{p[0] - offset[0], p[1] - offset[1]}
+		}
+	}
+	opts.Overlays = shifted
+
+	return m.RenderWith(format, opts)
+}
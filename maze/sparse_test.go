@@ -0,0 +1,114 @@
+package maze
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+	"github.com/jack-barr3tt/gostuff/types"
+)
+
+func TestSparseGrid(t *testing.T) {
+	g := NewSparseGrid('.')
+
+	test.AssertEqual(t, g.At(types.Point{-100, 5}), '.')
+	test.AssertEqual(t, g.Has(types.Point{-100, 5}), false)
+
+	g.Set(types.Point{-100, 5}, '#')
+	test.AssertEqual(t, g.At(types.Point{-100, 5}), '#')
+	test.AssertEqual(t, g.Has(types.Point{-100, 5}), true)
+	test.AssertEqual(t, g.Len(), 1)
+
+	// setting the default clears the cell
+	g.Set(types.Point{-100, 5}, '.')
+	test.AssertEqual(t, g.Len(), 0)
+	test.AssertEqual(t, g.Default(), '.')
+}
+
+func TestSparseGridBounds(t *testing.T) {
+	g := NewSparseGrid(0)
+
+	_, _, ok := g.Bounds()
+	test.AssertEqual(t, ok, false)
+
+	g.Set(types.Point{2, 3}, 1)
+	g.Set(types.Point{-1, 7}, 1)
+	g.Set(types.Point{0, 0}, 1)
+
+	min, max, ok := g.Bounds()
+	test.AssertEqual(t, ok, true)
+	test.AssertEqual(t, min, types.Point{-1, 0})
+	test.AssertEqual(t, max, types.Point{2, 7})
+
+	// the bounds shrink when an edge cell is removed
+	g.Delete(types.Point{-1, 7})
+	min, max, _ = g.Bounds()
+	test.AssertEqual(t, min, types.Point{0, 0})
+	test.AssertEqual(t, max, types.Point{2, 3})
+
+	g.Delete(types.Point{0, 0})
+	g.Delete(types.Point{2, 3})
+	g.Set(types.Point{9, 9}, 1)
+	min, max, _ = g.Bounds()
+	test.AssertEqual(t, min, types.Point{9, 9})
+	test.AssertEqual(t, max, types.Point{9, 9})
+}
+
+func TestSparseGridIteration(t *testing.T) {
+	g := NewSparseGrid(false)
+	g.Set(types.Point{1, 1}, true)
+	g.Set(types.Point{0, 1}, true)
+	g.Set(types.Point{5, -2}, true)
+
+	test.AssertEqual(t, g.Points(), []types.Point{{5, -2}, {0, 1}, {1, 1}})
+
+	count := 0
+	g.Each(func(p types.Point, v bool) {
+		test.AssertEqual(t, v, true)
+		count++
+	})
+	test.AssertEqual(t, count, 3)
+
+	clone := g.Clone()
+	clone.Delete(types.Point{1, 1})
+	test.AssertEqual(t, g.Len(), 3)
+	test.AssertEqual(t, clone.Len(), 2)
+}
+
+func TestSparseGridMaze(t *testing.T) {
+	m := NewMaze(`#..
+.#.
+..#`)
+
+	g := SparseFromMaze(m, '.')
+	test.AssertEqual(t, g.Len(), 3)
+
+	back, offset := g.ToMaze()
+	test.AssertEqual(t, back, m)
+	test.AssertEqual(t, offset, types.Point{0, 0})
+
+	// shifting the cells moves the offset but not the maze
+	shifted := NewSparseGrid('.')
+	g.Each(func(p types.Point, v rune) {
+		shifted.Set(types.Point{p[0] - 10, p[1] + 4}, v)
+	})
+	back, offset = shifted.ToMaze()
+	test.AssertEqual(t, back, m)
+	test.AssertEqual(t, offset, types.Point{-10, 4})
+
+	empty, _ := NewSparseGrid('.').ToMaze()
+	test.AssertEqual(t, len(empty), 0)
+}
+
+func TestSparseGridRender(t *testing.T) {
+	g := NewSparseGrid('.')
+	g.Set(types.Point{-2, 0}, '#')
+	g.Set(types.Point{0, 1}, '#')
+
+	test.AssertEqual(t, g.Render(runeString), "..#\n#..\n")
+
+	rendered := g.RenderWith(runeString, RenderOptions{
+		Orientation: types.YDown,
+		Overlays:    []Overlay{{Points: []types.Point{{-1, 0}, {50, 50}}, Mark: "@"}},
+	})
+	test.AssertEqual(t, rendered, "#@.\n..#\n")
+}
@@ -0,0 +1,118 @@
+package memo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jack-barr3tt/gostuff/cache"
+)
+
+var ErrCycle = errors.New("memoised function depends on itself")
+
+// Stats counts how often a memoised function found a result already computed
+type Stats struct {
+	Hits   int
+	Misses int
+}
+
+// Memo wraps a recursive function so each key is only computed once
+type Memo[K comparable, V any] struct {
+	fn      func(recurse func(K) V, k K) V
+	values  map[K]V
+	bounded *cache.LRU[K, V]
+	active  map[K]bool
+	stats   Stats
+}
+
+// cycle is panicked through the recursion to unwind it once a cycle is found
+type cycle[K comparable] struct {
+	key K
+}
+
+// Memoize wraps fn so results are cached by key. fn is given recurse to call itself with,
+// which goes through the cache too. Every result is kept until Reset is called.
+func Memoize[K comparable, V any](fn func(recurse func(K) V, k K) V) *Memo[K, V] {
+	return &Memo[K, V]{fn: fn, values: make(map[K]V), active: make(map[K]bool)}
+}
+
+// MemoizeBounded is Memoize, but only keeps the size most recently used results
+func MemoizeBounded[K comparable, V any](fn func(recurse func(K) V, k K) V, size int) *Memo[K, V] {
+	return &Memo[K, V]{fn: fn, bounded: cache.NewLRU[K, V](size, nil), active: make(map[K]bool)}
+}
+
+func (m *Memo[K, V]) lookup(k K) (V, bool) {
+	if m.bounded != nil {
+		return m.bounded.Get(k)
+	}
+	v, ok := m.values[k]
+	return v, ok
+}
+
+func (m *Memo[K, V]) store(k K, v V) {
+	if m.bounded != nil {
+		m.bounded.Put(k, v)
+	} else {
+		m.values[k] = v
+	}
+}
+
+func (m *Memo[K, V]) call(k K) V {
+	if v, ok := m.lookup(k); ok {
+		m.stats.Hits++
+		return v
+	}
+	if m.active[k] {
+		panic(cycle[K]{key: k})
+	}
+
+	m.stats.Misses++
+	m.active[k] = true
+	v := m.fn(m.call, k)
+	delete(m.active, k)
+
+	m.store(k, v)
+	return v
+}
+
+// Get returns the result for a key, computing it if needed.
+// If computing it needs the result of a key that is still being computed, an error matching
+// ErrCycle is returned instead of recursing forever. Results finished before the cycle was found stay cached.
+func (m *Memo[K, V]) Get(k K) (v V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.active = make(map[K]bool)
+			c, ok := r.(cycle[K])
+			if !ok {
+				panic(r)
+			}
+			var zero V
+			v, err = zero, fmt.Errorf("%w: %v", ErrCycle, c.key)
+		}
+	}()
+
+	return m.call(k), nil
+}
+
+// Stats returns the number of cache hits and misses so far
+func (m *Memo[K, V]) Stats() Stats {
+	return m.stats
+}
+
+// Len returns the number of results cached
+func (m *Memo[K, V]) Len() int {
+	if m.bounded != nil {
+		return m.bounded.Len()
+	}
+	return len(m.values)
+}
+
+// Reset forgets every cached result and the stats
+func (m *Memo[K, V]) Reset() {
+	if m.bounded != nil {
+		m.bounded = cache.NewLRU[K, V](m.bounded.Capacity(), nil)
+	} else {
+		m.values = make(map[K]V)
+	}
+	m.active = make(map[K]bool)
+	m.stats = Stats{}
+}
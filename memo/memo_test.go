@@ -0,0 +1,98 @@
+package memo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func fibonacci() *Memo[int, int] {
+	return Memoize(func(fib func(int) int, n int) int {
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+}
+
+func TestMemoize(t *testing.T) {
+	fib := fibonacci()
+
+	v, err := fib.Get(90)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, v, 2880067194370816120)
+
+	// each value is only computed once
+	test.AssertEqual(t, fib.Stats(), Stats{Hits: 88, Misses: 91})
+	test.AssertEqual(t, fib.Len(), 91)
+
+	fib.Get(50)
+	test.AssertEqual(t, fib.Stats().Hits, 89)
+
+	fib.Reset()
+	test.AssertEqual(t, fib.Len(), 0)
+	test.AssertEqual(t, fib.Stats(), Stats{})
+}
+
+func TestMemoizeStruct(t *testing.T) {
+	type pos struct{ x, y int }
+
+	// count the lattice paths to the origin
+	paths := Memoize(func(paths func(pos) int, p pos) int {
+		if p.x == 0 || p.y == 0 {
+			return 1
+		}
+		return paths(pos{p.x - 1, p.y}) + paths(pos{p.x, p.y - 1})
+	})
+
+	v, _ := paths.Get(pos{16, 16})
+	test.AssertEqual(t, v, 601080390)
+}
+
+func TestMemoizeBounded(t *testing.T) {
+	fib := MemoizeBounded(func(fib func(int) int, n int) int {
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	}, 3)
+
+	v, err := fib.Get(40)
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, v, 102334155)
+	test.AssertEqual(t, fib.Len(), 3)
+
+	// the most recent results are still there
+	fib.Get(40)
+	test.AssertEqual(t, fib.Stats().Hits, 39)
+}
+
+func TestMemoizeCycle(t *testing.T) {
+	// a depends on b, which depends on a
+	deps := map[string][]string{"a": {"b"}, "b": {"a"}, "c": {"d"}, "d": {}}
+	depth := Memoize(func(depth func(string) int, k string) int {
+		best := 0
+		for _, d := range deps[k] {
+			if v := depth(d) + 1; v > best {
+				best = v
+			}
+		}
+		return best
+	})
+
+	_, err := depth.Get("a")
+	test.AssertEqual(t, errors.Is(err, ErrCycle), true)
+
+	// nothing was left half computed
+	v, err := depth.Get("c")
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, v, 1)
+	test.AssertEqual(t, depth.Len(), 2)
+
+	// other panics are left alone
+	defer func() {
+		test.AssertEqual(t, recover(), "boom")
+	}()
+	Memoize(func(f func(int) int, n int) int { panic("boom") }).Get(1)
+}
@@ -0,0 +1,196 @@
+package search
+
+import (
+	"math"
+	"sort"
+
+	"github.com/jack-barr3tt/gostuff/cache"
+)
+
+// Step is a move from one state to a neighbouring state and what it costs
+type Step[S any] struct {
+	State S
+	Cost  int
+}
+
+// Options tune the searches in this package. The zero value has no limits and no transposition table.
+type Options struct {
+	// MaxDepth is the most moves a path may have. Zero means no limit.
+	MaxDepth int
+	// TranspositionSize is how many states to remember the cheapest cost of reaching, so later
+	// routes that reach them for no less are cut. The least recently used states are forgotten first.
+	// Zero disables the table.
+	TranspositionSize int
+}
+
+// IDAStar finds the cheapest path from start to a state satisfying goal with iterative deepening A*.
+// Only the current path is kept in memory, at the cost of exploring states again on every iteration.
+// The heuristic must not overestimate the remaining cost, and should return -1 for states that can't reach the goal.
+// Returns the states along the path and its cost, or nil, -1 if no path exists within the limits.
+func IDAStar[S comparable](start S, neighbours func(s S) []Step[S], heuristic func(s S) int, goal func(s S) bool, opts Options) ([]S, int) {
+	path := []S{start}
+	onPath := map[S]bool{start: true}
+	var table *cache.LRU[S, int]
+	bound := 0
+	found, cost := false, -1
+
+	// dfs returns the smallest estimate that went over the bound, or math.MaxInt if nothing did
+	var dfs func(s S, g int) int
+	dfs = func(s S, g int) int {
+		h := heuristic(s)
+		if h == -1 {
+			return math.MaxInt
+		}
+		if f := g + h; f > bound {
+			return f
+		}
+		if goal(s) {
+			found, cost = true, g
+			return g
+		}
+		if opts.MaxDepth > 0 && len(path) > opts.MaxDepth {
+			return math.MaxInt
+		}
+		if table != nil {
+			if best, ok := table.Get(s); ok && best <= g {
+				return math.MaxInt
+			}
+			table.Put(s, g)
+		}
+
+		next := math.MaxInt
+		for _, step := range neighbours(s) {
+			if onPath[step.State] {
+				continue
+			}
+
+			path = append(path, step.State)
+			onPath[step.State] = true
+			t := dfs(step.State, g+step.Cost)
+			if found {
+				return t
+			}
+			path = path[:len(path)-1]
+			delete(onPath, step.State)
+
+			if t < next {
+				next = t
+			}
+		}
+		return next
+	}
+
+	for {
+		// costs recorded under a lower bound would cut paths that are now allowed
+		if opts.TranspositionSize > 0 {
+			table = cache.NewLRU[S, int](opts.TranspositionSize, nil)
+		}
+
+		next := dfs(start, 0)
+		if found {
+			return path, cost
+		}
+		if next == math.MaxInt {
+			return nil, -1
+		}
+		bound = next
+	}
+}
+
+// beamNode is a state in the beam, linked back through the states before it
+type beamNode[S any] struct {
+	state S
+	cost  int
+	f     int
+	prev  *beamNode[S]
+}
+
+func (n *beamNode[S]) path() []S {
+	path := []S{}
+	for curr := n; curr != nil; curr = curr.prev {
+		path = append([]S{curr.state}, path...)
+	}
+	return path
+}
+
+// Beam searches outwards from start one move at a time, only keeping the width states with the
+// lowest cost plus heuristic at each depth. Memory is bounded by the width, but the path found is
+// not guaranteed to be the cheapest, and a narrow beam can miss the goal entirely.
+// The heuristic should return -1 for states that can't reach the goal.
+// Returns the cheapest path to a goal in the first layer that reaches one, or nil, -1 if none is found.
+// Without MaxDepth this only ends when the beam runs out of states.
+func Beam[S comparable](start S, neighbours func(s S) []Step[S], heuristic func(s S) int, goal func(s S) bool, width int, opts Options) ([]S, int) {
+	if heuristic(start) == -1 {
+		return nil, -1
+	}
+	if goal(start) {
+		return []S{start}, 0
+	}
+
+	var table *cache.LRU[S, int]
+	if opts.TranspositionSize > 0 {
+		table = cache.NewLRU[S, int](opts.TranspositionSize, nil)
+		table.Put(start, 0)
+	}
+
+	beam := []*beamNode[S]{{state: start}}
+	for depth := 1; len(beam) > 0 && (opts.MaxDepth == 0 || depth <= opts.MaxDepth); depth++ {
+		// only the cheapest way into each state is kept, in the order states were first reached
+		next := []*beamNode[S]{}
+		index := map[S]int{}
+		for _, node := range beam {
+			for _, step := range neighbours(node.state) {
+				cost := node.cost + step.Cost
+				i, seen := index[step.State]
+				if seen && next[i].cost <= cost {
+					continue
+				}
+				if table != nil {
+					if best, ok := table.Get(step.State); ok && best <= cost {
+						continue
+					}
+				}
+
+				h := heuristic(step.State)
+				if h == -1 {
+					continue
+				}
+				candidate := &beamNode[S]{state: step.State, cost: cost, f: cost + h, prev: node}
+				if seen {
+					next[i] = candidate
+				} else {
+					index[step.State] = len(next)
+					next = append(next, candidate)
+				}
+			}
+		}
+
+		// ties are broken by cost, then by the order states were reached, so the result is reproducible
+		sort.SliceStable(next, func(i, j int) bool {
+			if next[i].f != next[j].f {
+				return next[i].f < next[j].f
+			}
+			return next[i].cost < next[j].cost
+		})
+		if len(next) > width {
+			next = next[:width]
+		}
+
+		var best *beamNode[S]
+		for _, node := range next {
+			if table != nil {
+				table.Put(node.state, node.cost)
+			}
+			if goal(node.state) && (best == nil || node.cost < best.cost) {
+				best = node
+			}
+		}
+		if best != nil {
+			return best.path(), best.cost
+		}
+
+		beam = next
+	}
+
+	return nil, -1
+}
@@ -0,0 +1,113 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/nums"
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+// an 8 puzzle board read left to right, top to bottom, with 0 as the gap
+type board [9]int
+
+var solved = board{1, 2, 3, 4, 5, 6, 7, 8, 0}
+
+func slides(b board) []Step[board] {
+	gap := 0
+	for i, v := range b {
+		if v == 0 {
+			gap = i
+		}
+	}
+
+	steps := []Step[board]{}
+	for _, d := range []int{-3, 3, -1, 1} {
+		to := gap + d
+		if to < 0 || to > 8 || (d == -1 && gap%3 == 0) || (d == 1 && gap%3 == 2) {
+			continue
+		}
+		next := b
+		next[gap], next[to] = next[to], next[gap]
+		steps = append(steps, Step[board]{State: next, Cost: 1})
+	}
+	return steps
+}
+
+func manhattan(b board) int {
+	dist := 0
+	for i, v := range b {
+		if v != 0 {
+			want := v - 1
+			dist += nums.Abs(i/3-want/3) + nums.Abs(i%3-want%3)
+		}
+	}
+	return dist
+}
+
+func isSolved(b board) bool {
+	return b == solved
+}
+
+func TestIDAStar(t *testing.T) {
+	start := board{0, 1, 3, 4, 2, 5, 7, 8, 6}
+
+	path, cost := IDAStar(start, slides, manhattan, isSolved, Options{})
+	test.AssertEqual(t, cost, 4)
+	test.AssertEqual(t, len(path), 5)
+	test.AssertEqual(t, path[0], start)
+	test.AssertEqual(t, path[4], solved)
+
+	// a harder board needs many iterations
+	hard := board{8, 6, 7, 2, 5, 4, 3, 0, 1}
+	_, cost = IDAStar(hard, slides, manhattan, isSolved, Options{TranspositionSize: 100000})
+	test.AssertEqual(t, cost, 31)
+
+	_, cost = IDAStar(start, slides, manhattan, isSolved, Options{MaxDepth: 3})
+	test.AssertEqual(t, cost, -1)
+
+	_, cost = IDAStar(solved, slides, manhattan, isSolved, Options{})
+	test.AssertEqual(t, cost, 0)
+
+	// swapping two tiles makes the puzzle unsolvable, which the heuristic can rule out
+	_, cost = IDAStar(board{2, 1, 3, 4, 5, 6, 7, 8, 0}, slides, func(b board) int {
+		if b[0] == 2 && b[1] == 1 {
+			return -1
+		}
+		return manhattan(b)
+	}, isSolved, Options{})
+	test.AssertEqual(t, cost, -1)
+}
+
+func TestBeam(t *testing.T) {
+	start := board{0, 1, 3, 4, 2, 5, 7, 8, 6}
+
+	path, cost := Beam(start, slides, manhattan, isSolved, 10, Options{})
+	test.AssertEqual(t, cost, 4)
+	test.AssertEqual(t, path[len(path)-1], solved)
+
+	hard := board{8, 6, 7, 2, 5, 4, 3, 0, 1}
+	path, cost = Beam(hard, slides, manhattan, isSolved, 1000, Options{MaxDepth: 100, TranspositionSize: 100000})
+	test.AssertEqual(t, cost >= 31, true)
+	test.AssertEqual(t, len(path), cost+1)
+	for i := 1; i < len(path); i++ {
+		moved := false
+		for _, step := range slides(path[i-1]) {
+			moved = moved || step.State == path[i]
+		}
+		test.AssertEqual(t, moved, true)
+	}
+
+	_, cost = Beam(start, slides, manhattan, isSolved, 10, Options{MaxDepth: 3})
+	test.AssertEqual(t, cost, -1)
+
+	path, cost = Beam(solved, slides, manhattan, isSolved, 10, Options{})
+	test.AssertEqual(t, path, []board{solved})
+	test.AssertEqual(t, cost, 0)
+
+	// a narrow beam full of ties keeps the same states every run
+	first, _ := Beam(hard, slides, manhattan, isSolved, 3, Options{MaxDepth: 40})
+	for i := 0; i < 20; i++ {
+		again, _ := Beam(hard, slides, manhattan, isSolved, 3, Options{MaxDepth: 40})
+		test.AssertEqual(t, again, first)
+	}
+}
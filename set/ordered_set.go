@@ -0,0 +1,61 @@
+package set
+
+// OrderedSet is a set that remembers the order items were first added in
+type OrderedSet[T comparable] struct {
+	items []T
+	index map[T]int
+}
+
+func NewOrdered[T comparable]() *OrderedSet[T] {
+	return &OrderedSet[T]{items: []T{}, index: make(map[T]int)}
+}
+
+func OrderedFromSlice[T comparable](items []T) *OrderedSet[T] {
+	s := NewOrdered[T]()
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+func (s *OrderedSet[T]) Add(item T) *OrderedSet[T] {
+	if _, exists := s.index[item]; !exists {
+		s.index[item] = len(s.items)
+		s.items = append(s.items, item)
+	}
+	return s
+}
+
+func (s *OrderedSet[T]) Remove(item T) *OrderedSet[T] {
+	i, exists := s.index[item]
+	if !exists {
+		return s
+	}
+	delete(s.index, item)
+	s.items = append(s.items[:i], s.items[i+1:]...)
+	for j := i; j < len(s.items); j++ {
+		s.index[s.items[j]] = j
+	}
+	return s
+}
+
+func (s *OrderedSet[T]) Has(item T) bool {
+	_, exists := s.index[item]
+	return exists
+}
+
+func (s *OrderedSet[T]) Size() int {
+	return len(s.items)
+}
+
+// ToSlice returns the items in the order they were added
+func (s *OrderedSet[T]) ToSlice() []T {
+	items := make([]T, len(s.items))
+	copy(items, s.items)
+	return items
+}
+
+// ToSet converts to an unordered Set
+func (s *OrderedSet[T]) ToSet() Set[T] {
+	return FromSlice(s.items)
+}
@@ -0,0 +1,37 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestOrderedSet(t *testing.T) {
+	s := NewOrdered[string]()
+	test.AssertEqual(t, s.Size(), 0)
+	test.AssertEqual(t, s.ToSlice(), []string{})
+
+	s.Add("c").Add("a").Add("b").Add("a")
+
+	test.AssertEqual(t, s.Size(), 3)
+	test.AssertEqual(t, s.ToSlice(), []string{"c", "a", "b"})
+	test.AssertEqual(t, s.Has("a"), true)
+	test.AssertEqual(t, s.Has("d"), false)
+
+	s.Remove("c").Remove("d")
+	test.AssertEqual(t, s.ToSlice(), []string{"a", "b"})
+	test.AssertEqual(t, s.Has("c"), false)
+
+	// indexes stay correct after a removal
+	s.Remove("b")
+	test.AssertEqual(t, s.ToSlice(), []string{"a"})
+
+	test.AssertEqual(t, s.ToSet().Has("a"), true)
+	test.AssertEqual(t, s.ToSet().Size(), 1)
+}
+
+func TestOrderedFromSlice(t *testing.T) {
+	s := OrderedFromSlice([]int{3, 1, 3, 2, 1})
+
+	test.AssertEqual(t, s.ToSlice(), []int{3, 1, 2})
+}
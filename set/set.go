@@ -1,5 +1,10 @@
 package set
 
+import (
+	"cmp"
+	"slices"
+)
+
 type Set[T comparable] struct {
 	data map[T]bool
 }
@@ -32,6 +37,13 @@ func (s Set[T]) ToSlice() []T {
 	return items
 }
 
+// Sorted returns the items of a set in ascending order, so iteration is reproducible
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	items := s.ToSlice()
+	slices.Sort(items)
+	return items
+}
+
 func (s Set[T]) Add(item T) Set[T] {
 	s.data[item] = true
 	return s
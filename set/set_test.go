@@ -40,6 +40,12 @@ func TestToSlice(t *testing.T) {
 	test.AssertSlicesEqual(t, slice, []int{1, 2, 3})
 }
 
+func TestSorted(t *testing.T) {
+	test.AssertEqual(t, Sorted(FromSlice([]int{3, 1, 2})), []int{1, 2, 3})
+	test.AssertEqual(t, Sorted(FromSlice([]string{"b", "c", "a"})), []string{"a", "b", "c"})
+	test.AssertEqual(t, Sorted(New[int]()), []int{})
+}
+
 func TestAdd(t *testing.T) {
 	s := FromSlice([]int{1, 2})
 
@@ -227,6 +227,10 @@ func ParallelMap[A any, B any](fn func(A) B, inputs []A, coreCount int) []B {
 	// Launch workers
 	for i := 0; i < coreCount; i++ {
 		start := i * chunkSize
+		// rounding the chunk size up can use up the inputs before every worker has a chunk
+		if start >= len(inputs) {
+			break
+		}
 		end := (i + 1) * chunkSize
 		if end > len(inputs) {
 			end = len(inputs)
@@ -305,6 +305,9 @@ func TestParallelMap(t *testing.T) {
 	// test different input and output type
 	test.AssertEqual(t, ParallelMap(func(x string) int { return len(x) }, []string{"a", "ab", "abc"}, 4), []int{1, 2, 3})
 
+	// test worker counts that leave the last workers without a chunk
+	test.AssertEqual(t, ParallelMap(func(x int) int { return x * 2 }, []int{1, 2, 3, 4, 5}, 4), []int{2, 4, 6, 8, 10})
+
 	testFunc := func(x int) int {
 		time.Sleep(time.Second)
 		return x * 2
@@ -0,0 +1,240 @@
+package tree
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jack-barr3tt/gostuff/graphs"
+)
+
+// Tree is a graph rooted at one node. Edges are treated as undirected, so a tree can be
+// loaded with edges pointing either towards or away from the root.
+type Tree struct {
+	Root     string
+	Parent   map[string]string
+	Children map[string][]string
+	// Depth is the number of edges between a node and the root
+	Depth map[string]int
+	// Dist is the total edge cost between a node and the root
+	Dist map[string]int
+
+	order []string
+	index map[string]int
+	// up[k][i] is the index of the 2^k-th ancestor of node i, or -1 past the root
+	up [][]int
+}
+
+// FromGraph roots the tree held in a graph at root.
+// Returns an error if root is unknown, or the graph has a cycle or more than one component.
+func FromGraph(g graphs.Graph, root string) (*Tree, error) {
+	if _, ok := g.At(root); !ok {
+		return nil, fmt.Errorf("root %s is not in the graph", root)
+	}
+
+	// an edge stored in both directions is still one edge
+	neighbours := make(map[string]map[string]int)
+	addNeighbour := func(a, b string, cost int) {
+		if neighbours[a] == nil {
+			neighbours[a] = make(map[string]int)
+		}
+		if _, ok := neighbours[a][b]; !ok {
+			neighbours[a][b] = cost
+		}
+	}
+	names := g.GetNodesSorted()
+	for _, name := range names {
+		for _, edge := range g.GetEdges(name) {
+			if edge.Node == name {
+				return nil, fmt.Errorf("%s has an edge to itself", name)
+			}
+			addNeighbour(name, edge.Node, edge.Cost)
+			addNeighbour(edge.Node, name, edge.Cost)
+		}
+	}
+
+	t := &Tree{
+		Root:     root,
+		Parent:   make(map[string]string),
+		Children: make(map[string][]string),
+		Depth:    map[string]int{root: 0},
+		Dist:     map[string]int{root: 0},
+		order:    []string{root},
+		index:    map[string]int{root: 0},
+	}
+
+	for i := 0; i < len(t.order); i++ {
+		curr := t.order[i]
+		next := []string{}
+		for n := range neighbours[curr] {
+			if n != t.Parent[curr] || curr == root {
+				next = append(next, n)
+			}
+		}
+		sort.Strings(next)
+
+		for _, n := range next {
+			if _, seen := t.Depth[n]; seen {
+				return nil, fmt.Errorf("graph has a cycle through %s", n)
+			}
+			t.Parent[n] = curr
+			t.Children[curr] = append(t.Children[curr], n)
+			t.Depth[n] = t.Depth[curr] + 1
+			t.Dist[n] = t.Dist[curr] + neighbours[curr][n]
+			t.index[n] = len(t.order)
+			t.order = append(t.order, n)
+		}
+	}
+
+	if len(t.order) != len(names) {
+		return nil, fmt.Errorf("graph is not connected: %d of %d nodes reachable from %s", len(t.order), len(names), root)
+	}
+
+	t.buildLifting()
+	return t, nil
+}
+
+func (t *Tree) buildLifting() {
+	n := len(t.order)
+	levels := 1
+	for 1<<levels < n {
+		levels++
+	}
+
+	t.up = make([][]int, levels)
+	t.up[0] = make([]int, n)
+	for i, name := range t.order {
+		if parent, ok := t.Parent[name]; ok {
+			t.up[0][i] = t.index[parent]
+		} else {
+			t.up[0][i] = -1
+		}
+	}
+	for k := 1; k < levels; k++ {
+		t.up[k] = make([]int, n)
+		for i := range t.up[k] {
+			if mid := t.up[k-1][i]; mid != -1 {
+				t.up[k][i] = t.up[k-1][mid]
+			} else {
+				t.up[k][i] = -1
+			}
+		}
+	}
+}
+
+// Nodes returns every node in breadth first order from the root
+func (t *Tree) Nodes() []string {
+	nodes := make([]string, len(t.order))
+	copy(nodes, t.order)
+	return nodes
+}
+
+// Has returns whether a node is in the tree
+func (t *Tree) Has(node string) bool {
+	_, ok := t.index[node]
+	return ok
+}
+
+// Ancestor returns the node k steps above node. Returns false if that is past the root.
+func (t *Tree) Ancestor(node string, k int) (string, bool) {
+	i, ok := t.index[node]
+	if !ok || k < 0 || k > t.Depth[node] {
+		return "", false
+	}
+	return t.order[t.ancestor(i, k)], true
+}
+
+func (t *Tree) ancestor(i, k int) int {
+	for level := 0; k > 0; level++ {
+		if k&1 == 1 {
+			i = t.up[level][i]
+		}
+		k >>= 1
+	}
+	return i
+}
+
+// LCA returns the lowest common ancestor of two nodes using binary lifting.
+// Returns false if either node is not in the tree.
+func (t *Tree) LCA(a, b string) (string, bool) {
+	i, okA := t.index[a]
+	j, okB := t.index[b]
+	if !okA || !okB {
+		return "", false
+	}
+
+	// lift the deeper node to the same depth, then lift both until just below the ancestor
+	if t.Depth[a] < t.Depth[b] {
+		i, j = j, i
+		a, b = b, a
+	}
+	i = t.ancestor(i, t.Depth[a]-t.Depth[b])
+	if i == j {
+		return t.order[i], true
+	}
+
+	for k := len(t.up) - 1; k >= 0; k-- {
+		if t.up[k][i] != t.up[k][j] {
+			i, j = t.up[k][i], t.up[k][j]
+		}
+	}
+	return t.order[t.up[0][i]], true
+}
+
+// PathBetween returns the nodes from a to b, going up to their lowest common ancestor and back down.
+// Returns nil if either node is not in the tree.
+func (t *Tree) PathBetween(a, b string) []string {
+	lca, ok := t.LCA(a, b)
+	if !ok {
+		return nil
+	}
+
+	path := []string{a}
+	for curr := a; curr != lca; {
+		curr = t.Parent[curr]
+		path = append(path, curr)
+	}
+
+	down := []string{}
+	for curr := b; curr != lca; curr = t.Parent[curr] {
+		down = append(down, curr)
+	}
+	for i := len(down) - 1; i >= 0; i-- {
+		path = append(path, down[i])
+	}
+	return path
+}
+
+// Distance returns the total edge cost between two nodes, or -1 if either is not in the tree
+func (t *Tree) Distance(a, b string) int {
+	lca, ok := t.LCA(a, b)
+	if !ok {
+		return -1
+	}
+	return t.Dist[a] + t.Dist[b] - 2*t.Dist[lca]
+}
+
+// Aggregate computes a value for every node from the values of its children, deepest nodes first.
+// For a leaf, children is empty.
+func Aggregate[V any](t *Tree, fn func(node string, children []V) V) map[string]V {
+	values := make(map[string]V, len(t.order))
+	for i := len(t.order) - 1; i >= 0; i-- {
+		node := t.order[i]
+		children := make([]V, len(t.Children[node]))
+		for j, child := range t.Children[node] {
+			children[j] = values[child]
+		}
+		values[node] = fn(node, children)
+	}
+	return values
+}
+
+// SubtreeSizes returns the number of nodes in the subtree under every node, including itself
+func (t *Tree) SubtreeSizes() map[string]int {
+	return Aggregate(t, func(node string, children []int) int {
+		size := 1
+		for _, c := range children {
+			size += c
+		}
+		return size
+	})
+}
@@ -0,0 +1,145 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/graphs"
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+// From Advent of Code 2019 Day 6 example
+const orbits = `COM)B
+B)C
+C)D
+D)E
+E)F
+B)G
+G)H
+D)I
+E)J
+J)K
+K)L
+K)YOU
+I)SAN`
+
+func orbitTree(t *testing.T) *Tree {
+	g := graphs.NewEmptyGraph()
+	for _, line := range strings.Split(orbits, "\n") {
+		parts := strings.Split(line, ")")
+		g.AddNode(parts[0], nil)
+		g.AddNode(parts[1], nil)
+		// orbits point at what they orbit, away from the root
+		g.AddEdge(parts[1], parts[0], 1)
+	}
+
+	tr, err := FromGraph(g, "COM")
+	test.AssertEqual(t, err, nil)
+	return tr
+}
+
+func TestFromGraph(t *testing.T) {
+	tr := orbitTree(t)
+
+	test.AssertEqual(t, tr.Parent["B"], "COM")
+	test.AssertEqual(t, tr.Children["B"], []string{"C", "G"})
+	test.AssertEqual(t, tr.Depth["L"], 7)
+	test.AssertEqual(t, tr.Nodes()[:3], []string{"COM", "B", "C"})
+
+	total := 0
+	for _, d := range tr.Depth {
+		total += d
+	}
+	test.AssertEqual(t, total, 54)
+
+	// a cycle isn't a tree
+	g, _ := graphs.ParseAdjacencyList(`A: B
+B: C
+C: A`)
+	_, err := FromGraph(g, "A")
+	test.AssertNotEqual(t, err, nil)
+
+	// neither is a forest
+	g, _ = graphs.ParseAdjacencyList(`A: B
+C: D`)
+	_, err = FromGraph(g, "A")
+	test.AssertNotEqual(t, err, nil)
+
+	_, err = FromGraph(g, "Z")
+	test.AssertNotEqual(t, err, nil)
+
+	// edges stored both ways are fine
+	g, _ = graphs.ParseAdjacencyList(`A <-> B C`)
+	tr, err = FromGraph(g, "B")
+	test.AssertEqual(t, err, nil)
+	test.AssertEqual(t, tr.Depth["C"], 2)
+}
+
+func TestLCA(t *testing.T) {
+	tr := orbitTree(t)
+
+	lca, ok := tr.LCA("YOU", "SAN")
+	test.AssertEqual(t, ok, true)
+	test.AssertEqual(t, lca, "D")
+
+	lca, _ = tr.LCA("H", "L")
+	test.AssertEqual(t, lca, "B")
+
+	lca, _ = tr.LCA("E", "L")
+	test.AssertEqual(t, lca, "E")
+
+	lca, _ = tr.LCA("F", "F")
+	test.AssertEqual(t, lca, "F")
+
+	_, ok = tr.LCA("F", "Z")
+	test.AssertEqual(t, ok, false)
+
+	a, ok := tr.Ancestor("L", 3)
+	test.AssertEqual(t, ok, true)
+	test.AssertEqual(t, a, "E")
+
+	_, ok = tr.Ancestor("L", 8)
+	test.AssertEqual(t, ok, false)
+}
+
+func TestPathBetween(t *testing.T) {
+	tr := orbitTree(t)
+
+	path := tr.PathBetween("YOU", "SAN")
+	test.AssertEqual(t, path, []string{"YOU", "K", "J", "E", "D", "I", "SAN"})
+
+	// the orbital transfers don't count YOU and SAN themselves
+	test.AssertEqual(t, tr.Distance("YOU", "SAN")-2, 4)
+
+	test.AssertEqual(t, tr.PathBetween("D", "F"), []string{"D", "E", "F"})
+	test.AssertEqual(t, tr.Distance("D", "Z"), -1)
+
+	// edge costs are used for distances
+	g, _ := graphs.NewGraph([]string{"root", "a", "b"}, map[string][]graphs.Edge{
+		"root": {{Node: "a", Cost: 5}, {Node: "b", Cost: 2}},
+	})
+	tr, _ = FromGraph(g, "root")
+	test.AssertEqual(t, tr.Distance("a", "b"), 7)
+}
+
+func TestAggregate(t *testing.T) {
+	tr := orbitTree(t)
+
+	sizes := tr.SubtreeSizes()
+	test.AssertEqual(t, sizes["COM"], 14)
+	test.AssertEqual(t, sizes["K"], 3)
+	test.AssertEqual(t, sizes["L"], 1)
+
+	// the deepest node in each subtree
+	deepest := Aggregate(tr, func(node string, children []int) int {
+		d := tr.Depth[node]
+		for _, c := range children {
+			if c > d {
+				d = c
+			}
+		}
+		return d
+	})
+	test.AssertEqual(t, deepest["B"], 7)
+	test.AssertEqual(t, deepest["G"], 3)
+}
@@ -0,0 +1,42 @@
+package types
+
+// Orientation says which way y grows in a grid. The compass directions in this package are
+// for YUp, where North is {0, 1}. In YDown, as in puzzle text and on screen, North is {0, -1}.
+type Orientation int
+
+const (
+	YUp Orientation = iota
+	YDown
+)
+
+// Convert turns a direction from YUp into this orientation, so it points the same way on screen
+func (o Orientation) Convert(d Direction) Direction {
+	if o == YDown {
+		return Direction{d[0], -d[1]}
+	}
+	return d
+}
+
+func (o Orientation) North() Direction {
+	return o.Convert(North)
+}
+
+func (o Orientation) East() Direction {
+	return o.Convert(East)
+}
+
+func (o Orientation) South() Direction {
+	return o.Convert(South)
+}
+
+func (o Orientation) West() Direction {
+	return o.Convert(West)
+}
+
+// Rotate turns a direction clockwise on screen by deg degrees, like Direction.Rotate does for YUp
+func (o Orientation) Rotate(d Direction, deg int) Direction {
+	if o == YDown {
+		return d.Rotate(-deg)
+	}
+	return d.Rotate(deg)
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/jack-barr3tt/gostuff/test"
+)
+
+func TestOrientationDirections(t *testing.T) {
+	test.AssertEqual(t, YUp.North(), North)
+	test.AssertEqual(t, YUp.West(), West)
+
+	test.AssertEqual(t, YDown.North(), Direction{0, -1})
+	test.AssertEqual(t, YDown.South(), Direction{0, 1})
+	test.AssertEqual(t, YDown.East(), East)
+	test.AssertEqual(t, YDown.Convert(NorthEast), Direction{1, -1})
+}
+
+func TestOrientationRotate(t *testing.T) {
+	// turning right from north faces east in either orientation
+	test.AssertEqual(t, YUp.Rotate(YUp.North(), 90), YUp.East())
+	test.AssertEqual(t, YDown.Rotate(YDown.North(), 90), YDown.East())
+	test.AssertEqual(t, YDown.Rotate(YDown.East(), 90), YDown.South())
+	test.AssertEqual(t, YDown.Rotate(YDown.North(), -90), YDown.West())
+	test.AssertEqual(t, YDown.Rotate(YDown.North(), 45), YDown.Convert(NorthEast))
+}